@@ -0,0 +1,46 @@
+package linq
+
+// Aggregate applies an accumulator function over a sequence, the specified seed value is used as the initial accumulator value.
+func Aggregate[T, A any](items Enumerable[T], seed A, accumulator func(A, T) A) A {
+	res := seed
+	for elem := range items.Values() {
+		res = accumulator(res, elem)
+	}
+	return res
+}
+
+// AggregateWithResult applies an accumulator function over a sequence, the specified seed value is used as the initial accumulator value,
+// and the specified function is used to select the result value from the final accumulator value.
+func AggregateWithResult[T, A, R any](items Enumerable[T], seed A, accumulator func(A, T) A, resultSelector func(A) R) R {
+	return resultSelector(Aggregate(items, seed, accumulator))
+}
+
+// Reduce applies an accumulator function over a sequence, the first element is used as the initial accumulator value.
+// It returns ErrEmptySequence when the sequence is empty.
+func Reduce[T any](items Enumerable[T], accumulator func(T, T) T) (T, error) {
+	var res T
+	var found bool
+	for elem := range items.Values() {
+		if !found {
+			res, found = elem, true
+			continue
+		}
+		res = accumulator(res, elem)
+	}
+	if !found {
+		return res, ErrEmptySequence
+	}
+	return res, nil
+}
+
+// Scan applies an accumulator function over a sequence and returns every intermediate accumulator value.
+// The seed value is used as the initial accumulator value and is not part of the result.
+func Scan[T, A any](items Enumerable[T], seed A, accumulator func(A, T) A) Linq[A] {
+	res := []A{}
+	acc := seed
+	for elem := range items.Values() {
+		acc = accumulator(acc, elem)
+		res = append(res, acc)
+	}
+	return New(res)
+}
@@ -0,0 +1,51 @@
+package linq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Aggregate_Functions(t *testing.T) {
+	assert := assert.New(t)
+	words := New([]string{"the", "quick", "brown", "fox", "the"})
+	empty := New([]string{})
+	{ // Aggregate histogram
+		actual := Aggregate(words, map[string]int{}, func(m map[string]int, w string) map[string]int {
+			m[w]++
+			return m
+		})
+		assert.Equal(map[string]int{"the": 2, "quick": 1, "brown": 1, "fox": 1}, actual)
+	}
+	{ // Aggregate with an empty sequence returns the seed
+		assert.Equal(42, Aggregate(empty, 42, func(acc int, w string) int { return acc + len(w) }))
+	}
+	{ // AggregateWithResult
+		actual := AggregateWithResult(words, []string{}, func(acc []string, w string) []string {
+			return append(acc, strings.ToUpper(w))
+		}, func(acc []string) string { return strings.Join(acc, "-") })
+		assert.Equal("THE-QUICK-BROWN-FOX-THE", actual)
+	}
+	{ // Reduce
+		actual, err := Reduce(words, func(a, b string) string { return a + " " + b })
+		assert.NoError(err)
+		assert.Equal("the quick brown fox the", actual)
+	}
+	{ // Reduce with a single element
+		actual, err := Reduce(New([]int{7}), func(a, b int) int { return a * b })
+		assert.NoError(err)
+		assert.Equal(7, actual)
+	}
+	{ // Reduce with an empty sequence
+		_, err := Reduce(empty, func(a, b string) string { return a + b })
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // Scan running balance
+		actual := Scan(New([]int{100, -30, 45, -5}), 0, func(balance, tx int) int { return balance + tx })
+		assert.Equal([]int{100, 70, 115, 110}, actual.ToSlice())
+	}
+	{ // Scan with an empty sequence
+		assert.Empty(Scan(empty, 0, func(acc int, w string) int { return acc + len(w) }).ToSlice())
+	}
+}
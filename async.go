@@ -1,43 +1,254 @@
 package linq
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
-func RunInAsync[I comparable, O any](inputs Linq[I], delegate func(I) O) []O {
-	res := make([]O, inputs.Length())
-	m := sync.Mutex{}
-	wg := sync.WaitGroup{}
-	wg.Add(inputs.Length())
-	for i, input := range inputs.ToSlice() {
-		i := i
-		input := input
-		go func() {
-			defer wg.Done()
-			result := delegate(input)
-			m.Lock()
-			res[i] = result
-			m.Unlock()
-		}()
+// RunInAsync projects each element of a sequence into a new form, every element on its own goroutine.
+// ! this function panics with a *DelegatePanicError on the calling goroutine when the delegate panics, use TryRunInAsync to get it as an error.
+func RunInAsync[I comparable, O any](inputs Enumerable[I], delegate func(I) O) []O {
+	res, err := TryRunInAsync(inputs, delegate)
+	if err != nil {
+		var panicErr *DelegatePanicError
+		errors.As(err, &panicErr)
+		panic(panicErr)
 	}
-	wg.Wait()
 	return res
 }
 
+// TryRunInAsync projects each element of a sequence into a new form, every element on its own goroutine.
+// The panics of the delegate are recovered and returned as *DelegatePanicError, joined with errors.Join.
+func TryRunInAsync[I, O any](inputs Enumerable[I], delegate func(I) O) ([]O, error) {
+	return MapConcurrentWithOptions(inputs, AsyncOptions{Mode: CollectAll}, func(input I) (O, error) {
+		return delegate(input), nil
+	})
+}
+
+// RunInAsyncWithRoutineLimit performs the specified action on each element of linq[T] on at most limit goroutines,
+// and returns once every action has returned. A limit less than or equal to zero runs every element on its own goroutine.
+// A panicking action does not prevent the other elements from being processed.
+// ! this method panics with a *DelegatePanicError on the calling goroutine when an action panics, once every action has returned.
 func (linq linq[T]) RunInAsyncWithRoutineLimit(delegate func(T), limit int) {
-	inputs := make(chan T, 5)
-	go func() {
-		defer close(inputs)
-		linq.ForEach(func(t T) {
-			inputs <- t
-		})
+	_, err := MapConcurrentWithOptions(linq, AsyncOptions{Limit: limit, Mode: CollectAll}, func(t T) (struct{}, error) {
+		delegate(t)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		var panicErr *DelegatePanicError
+		errors.As(err, &panicErr)
+		panic(panicErr)
+	}
+}
+
+// RunInAsyncContext projects each element of a sequence into a new form, every element on its own goroutine.
+// Once ctx is done no more delegates are started and ctx.Err() is returned unless every element was already processed,
+// the delegates that are already running receive ctx and are expected to return early.
+func RunInAsyncContext[I, O any](ctx context.Context, inputs Enumerable[I], delegate func(context.Context, I) O) ([]O, error) {
+	return MapConcurrentContext(ctx, inputs, AsyncOptions{}, func(ctx context.Context, input I) (O, error) {
+		return delegate(ctx, input), nil
+	})
+}
+
+// RunInAsyncWithRoutineLimitContext performs the specified action on each element of linq[T] on at most limit goroutines,
+// and returns once every started action has returned.
+// Once ctx is done no more actions are started and ctx.Err() is returned, unless every action had already returned.
+// A panicking action does not prevent the other elements from being processed, the panics are returned as *DelegatePanicError.
+func (linq linq[T]) RunInAsyncWithRoutineLimitContext(ctx context.Context, delegate func(context.Context, T), limit int) error {
+	_, err := MapConcurrentContext(ctx, linq, AsyncOptions{Limit: limit, Mode: CollectAll}, func(ctx context.Context, t T) (struct{}, error) {
+		delegate(ctx, t)
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// ErrorMode specifies how the concurrent operators react to a delegate that returns an error.
+type ErrorMode int
+
+const (
+	// FailFast stops handing out elements after the first error and returns that error.
+	// The elements that are already being processed run to completion.
+	FailFast ErrorMode = iota
+	// CollectAll processes every element and returns all the errors joined with errors.Join, in the order of the elements.
+	CollectAll
+)
+
+// AsyncOptions configures the concurrent operators.
+type AsyncOptions struct {
+	// Limit is the maximum number of goroutines, a limit less than or equal to zero runs every element on its own goroutine.
+	Limit int
+	// Mode specifies how errors returned by the delegate are handled, FailFast by default.
+	Mode ErrorMode
+	// RateLimit is the maximum number of delegate calls started per second, retries included. No limit when not positive.
+	RateLimit float64
+	// Burst is the number of delegate calls that can be started at once before RateLimit applies, 1 when not positive.
+	Burst int
+	// Retry specifies how a delegate that returns an error is retried, the zero value does not retry.
+	Retry RetryPolicy
+	// Timeout is the maximum duration of a single delegate call, no timeout when not positive.
+	// A call that times out fails with context.DeadlineExceeded.
+	Timeout time.Duration
+	// Clock is used for the rate limit, the backoffs and the timeouts, the system clock when nil.
+	Clock Clock
+}
+
+// IndexedError is returned by the concurrent operators when the delegate fails for an element.
+type IndexedError struct {
+	// Index is the index of the element in the source.
+	Index int
+	// Err is the error returned by the delegate.
+	Err error
+}
+
+func (e *IndexedError) Error() string {
+	return fmt.Sprintf("linq: element %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
+
+// DelegatePanicError is returned by the concurrent operators when the delegate panics for an element.
+// The panic is recovered on the goroutine running the delegate, so it does not crash the process.
+type DelegatePanicError struct {
+	// Index is the index of the element in the source.
+	Index int
+	// Value is the element passed to the delegate.
+	Value any
+	// Recovered is the value passed to panic.
+	Recovered any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (e *DelegatePanicError) Error() string {
+	return fmt.Sprintf("linq: delegate panicked on element %d (%v): %v", e.Index, e.Value, e.Recovered)
+}
+
+// Unwrap returns the recovered value when it is an error.
+func (e *DelegatePanicError) Unwrap() error {
+	if err, ok := e.Recovered.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// callDelegate calls delegate with the element at index and converts a panic into a *DelegatePanicError.
+func callDelegate[T, R any](ctx context.Context, index int, elem T, delegate func(context.Context, T) (R, error)) (result R, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &DelegatePanicError{Index: index, Value: elem, Recovered: recovered, Stack: debug.Stack()}
+		}
 	}()
+	result, err = delegate(ctx, elem)
+	if err != nil {
+		err = &IndexedError{Index: index, Err: err}
+	}
+	return result, err
+}
+
+// MapConcurrent projects each element of a sequence into a new form on at most limit goroutines, in FailFast mode.
+// See MapConcurrentWithOptions for details.
+func MapConcurrent[T, R any](items Enumerable[T], limit int, delegate func(T) (R, error)) ([]R, error) {
+	return MapConcurrentWithOptions(items, AsyncOptions{Limit: limit}, delegate)
+}
+
+// MapConcurrentWithOptions projects each element of a sequence into a new form on at most opts.Limit goroutines.
+// The results are in the order of the source, whatever the order in which the delegates complete.
+// The errors are wrapped in an *IndexedError and the panics of the delegate are recovered as *DelegatePanicError,
+// use errors.Is or errors.As to inspect them.
+// When an error is returned, the results of the elements that failed or were not processed are left as zero values.
+func MapConcurrentWithOptions[T, R any](items Enumerable[T], opts AsyncOptions, delegate func(T) (R, error)) ([]R, error) {
+	return MapConcurrentContext(context.Background(), items, opts, func(_ context.Context, t T) (R, error) {
+		return delegate(t)
+	})
+}
+
+// MapConcurrentContext is the context-aware MapConcurrentWithOptions.
+// Once ctx is done no more delegates are started and ctx.Err() is returned in place of the errors of the delegates,
+// unless a delegate panicked. ctx.Err() is only returned when an element was skipped or failed because of ctx,
+// the results are returned as usual when every element was processed before the cancellation was noticed.
+// The delegates receive a context derived from ctx, in FailFast mode it is also canceled after the first error.
+func MapConcurrentContext[T, R any](ctx context.Context, items Enumerable[T], opts AsyncOptions, delegate func(context.Context, T) (R, error)) ([]R, error) {
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	inputs := collect(items)
+	res := make([]R, len(inputs))
+	errs := make([]error, len(inputs))
+	processed := make([]bool, len(inputs))
+	workers := len(inputs)
+	if opts.Limit > 0 {
+		workers = min(opts.Limit, workers)
+	}
 
-	consumer := func() {
-		for inp := range inputs {
-			delegate(inp)
+	exec := newExecutor(opts, delegate)
+	var next atomic.Int64
+	var failed atomic.Bool
+	var firstErr error
+	once := sync.Once{}
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if workerCtx.Err() != nil || opts.Mode == FailFast && failed.Load() {
+					return
+				}
+				i := int(next.Add(1) - 1)
+				if i >= len(inputs) {
+					return
+				}
+				result, err := exec.run(workerCtx, i, inputs[i])
+				processed[i] = true
+				if err != nil {
+					errs[i] = err
+					once.Do(func() { firstErr = errs[i] })
+					failed.Store(true)
+					if opts.Mode == FailFast {
+						cancel()
+					}
+					continue
+				}
+				res[i] = result
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil && interrupted(processed, errs) {
+		panics := []error{}
+		for _, e := range errs {
+			if _, ok := e.(*DelegatePanicError); ok {
+				panics = append(panics, e)
+			}
+		}
+		if len(panics) == 0 {
+			return res, err
 		}
+		return res, errors.Join(append([]error{err}, panics...)...)
+	}
+	if opts.Mode == FailFast {
+		return res, firstErr
 	}
+	return res, errors.Join(errs...)
+}
 
-	for i := 0; i < limit; i++ {
-		consumer()
+// interrupted reports whether an element was not processed or failed with an error other than a panic,
+// which are the elements that a done context may have skipped or aborted.
+func interrupted(processed []bool, errs []error) bool {
+	for i, err := range errs {
+		if !processed[i] {
+			return true
+		}
+		if _, ok := err.(*DelegatePanicError); err != nil && !ok {
+			return true
+		}
 	}
+	return false
 }
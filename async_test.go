@@ -1,7 +1,13 @@
 package linq
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,6 +17,7 @@ func Test_RunInAsync(t *testing.T) {
 	nums := New([]int{1, 2, 3, 4, 5})
 	double := RunInAsync(nums, func(i int) int { return 2 * i })
 	assert.Equal([]int{2, 4, 6, 8, 10}, double)
+	assert.Equal([]int{3, 6}, RunInAsync(Slice([]int{1, 2}), func(i int) int { return 3 * i }))
 }
 
 func Test_RunInAsyncWithRoutineLimit(t *testing.T) {
@@ -25,3 +32,324 @@ func Test_RunInAsyncWithRoutineLimit(t *testing.T) {
 	}
 	assert.ElementsMatch([]int{2, 4, 6, 8, 10}, actual)
 }
+
+// concurrencyProbe records the highest number of delegates running at the same time.
+// The first overlap delegates wait for each other before returning, so that they are known to have run at the same time.
+type concurrencyProbe struct {
+	running, peak, entered atomic.Int32
+	overlap                int32
+	release                chan struct{}
+	timedOut               atomic.Bool
+}
+
+func newConcurrencyProbe(overlap int) *concurrencyProbe {
+	return &concurrencyProbe{overlap: int32(overlap), release: make(chan struct{})}
+}
+
+func (p *concurrencyProbe) enter() {
+	current := p.running.Add(1)
+	for {
+		highest := p.peak.Load()
+		if current <= highest || p.peak.CompareAndSwap(highest, current) {
+			break
+		}
+	}
+	if p.entered.Add(1) == p.overlap {
+		close(p.release)
+	}
+	select {
+	case <-p.release:
+	case <-time.After(time.Second):
+		p.timedOut.Store(true)
+	}
+}
+
+func (p *concurrencyProbe) exit() {
+	p.running.Add(-1)
+}
+
+// overlapped reports whether the first overlap delegates ran at the same time.
+func (p *concurrencyProbe) overlapped() bool {
+	return !p.timedOut.Load() && p.peak.Load() >= p.overlap
+}
+
+func Test_RunInAsyncWithRoutineLimit_IsConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	probe := newConcurrencyProbe(3)
+	done := atomic.Int32{}
+	New(make([]int, 12)).RunInAsyncWithRoutineLimit(func(int) {
+		probe.enter()
+		probe.exit()
+		done.Add(1)
+	}, 3)
+	assert.Equal(int32(12), done.Load())
+	assert.True(probe.overlapped())
+	assert.LessOrEqual(probe.peak.Load(), int32(3))
+}
+
+func Test_MapConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	nums := New([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	{ // results keep the order of the source
+		actual, err := MapConcurrent(nums, 3, func(i int) (string, error) {
+			time.Sleep(time.Duration(8-i) * time.Millisecond)
+			return strconv.Itoa(i * i), nil
+		})
+		assert.NoError(err)
+		assert.Equal([]string{"1", "4", "9", "16", "25", "36", "49", "64"}, actual)
+	}
+	{ // the limit is respected
+		probe := newConcurrencyProbe(4)
+		_, err := MapConcurrent(New(make([]int, 20)), 4, func(i int) (int, error) {
+			probe.enter()
+			defer probe.exit()
+			return i, nil
+		})
+		assert.NoError(err)
+		assert.True(probe.overlapped())
+		assert.LessOrEqual(probe.peak.Load(), int32(4))
+	}
+	{ // the delegates run concurrently: each one waits for the other to start
+		started := sync.WaitGroup{}
+		started.Add(2)
+		_, err := MapConcurrent(New([]int{1, 2}), 2, func(i int) (int, error) {
+			started.Done()
+			waited := make(chan struct{})
+			go func() {
+				started.Wait()
+				close(waited)
+			}()
+			select {
+			case <-waited:
+				return i, nil
+			case <-time.After(time.Second):
+				return 0, errors.New("delegates did not run concurrently")
+			}
+		})
+		assert.NoError(err)
+	}
+	{ // no limit
+		probe := newConcurrencyProbe(10)
+		_, err := MapConcurrent(New(make([]int, 10)), 0, func(i int) (int, error) {
+			probe.enter()
+			defer probe.exit()
+			return i, nil
+		})
+		assert.NoError(err)
+		assert.True(probe.overlapped())
+		assert.LessOrEqual(probe.peak.Load(), int32(10))
+	}
+	{ // empty sequence
+		actual, err := MapConcurrent(New([]int{}), 2, func(i int) (int, error) { return i, nil })
+		assert.NoError(err)
+		assert.Empty(actual)
+	}
+}
+
+func Test_MapConcurrent_Errors(t *testing.T) {
+	assert := assert.New(t)
+	errOdd := errors.New("odd")
+	failOdd := func(i int) (int, error) {
+		if i%2 == 1 {
+			return 0, errOdd
+		}
+		return i * 10, nil
+	}
+	{ // FailFast stops handing out elements after the first error
+		calls := atomic.Int32{}
+		actual, err := MapConcurrent(New([]int{1, 2, 3, 4, 5, 6}), 1, func(i int) (int, error) {
+			calls.Add(1)
+			return failOdd(i)
+		})
+		assert.ErrorIs(err, errOdd)
+		var indexed *IndexedError
+		assert.ErrorAs(err, &indexed)
+		assert.Equal(0, indexed.Index)
+		assert.Equal(int32(1), calls.Load())
+		assert.Equal([]int{0, 0, 0, 0, 0, 0}, actual)
+	}
+	{ // CollectAll processes every element and joins the errors
+		actual, err := MapConcurrentWithOptions(New([]int{1, 2, 3, 4}), AsyncOptions{Limit: 2, Mode: CollectAll}, failOdd)
+		assert.ErrorIs(err, errOdd)
+		assert.Equal([]int{0, 20, 0, 40}, actual)
+		assert.Equal("linq: element 0: odd\nlinq: element 2: odd", err.Error())
+	}
+	{ // no error
+		_, err := MapConcurrentWithOptions(New([]int{2, 4}), AsyncOptions{Mode: CollectAll}, failOdd)
+		assert.NoError(err)
+	}
+}
+
+func Test_Async_Context(t *testing.T) {
+	assert := assert.New(t)
+	{ // RunInAsyncContext
+		actual, err := RunInAsyncContext(context.Background(), New([]int{1, 2, 3}), func(_ context.Context, i int) int { return i * 2 })
+		assert.NoError(err)
+		assert.Equal([]int{2, 4, 6}, actual)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = RunInAsyncContext(ctx, New([]int{1, 2, 3}), func(_ context.Context, i int) int { return i })
+		assert.ErrorIs(err, context.Canceled)
+	}
+	{ // RunInAsyncWithRoutineLimitContext stops starting actions once ctx is done
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := atomic.Int32{}
+		err := New(make([]int, 100)).RunInAsyncWithRoutineLimitContext(ctx, func(ctx context.Context, _ int) {
+			if calls.Add(1) == 5 {
+				cancel()
+			}
+		}, 1)
+		assert.ErrorIs(err, context.Canceled)
+		assert.Equal(int32(5), calls.Load())
+	}
+	{ // MapConcurrentContext with a deadline
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		calls := atomic.Int32{}
+		_, err := MapConcurrentContext(ctx, New(make([]int, 100)), AsyncOptions{Limit: 2}, func(ctx context.Context, i int) (int, error) {
+			calls.Add(1)
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(5 * time.Millisecond):
+				return i, nil
+			}
+		})
+		assert.ErrorIs(err, context.DeadlineExceeded)
+		assert.Less(calls.Load(), int32(100))
+	}
+	{ // MapConcurrentContext returns the results when ctx is canceled after the last element
+		ctx, cancel := context.WithCancel(context.Background())
+		actual, err := MapConcurrentContext(ctx, New([]int{1, 2, 3}), AsyncOptions{Limit: 1}, func(ctx context.Context, i int) (int, error) {
+			if i == 3 {
+				cancel()
+			}
+			return i * 10, nil
+		})
+		assert.NoError(err)
+		assert.Equal([]int{10, 20, 30}, actual)
+		assert.Error(ctx.Err())
+	}
+	{ // MapConcurrentContext cancels the running delegates after the first error in FailFast mode
+		errBoom := errors.New("boom")
+		_, err := MapConcurrentContext(context.Background(), New([]int{0, 1}), AsyncOptions{Limit: 2}, func(ctx context.Context, i int) (int, error) {
+			if i == 0 {
+				return 0, errBoom
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Second):
+				return 0, errors.New("the delegate was not canceled")
+			}
+		})
+		assert.ErrorIs(err, errBoom)
+	}
+}
+
+func Test_Channel_Context(t *testing.T) {
+	assert := assert.New(t)
+	{ // ToChannelWithBufferContext closes the channel once ctx is done
+		ctx, cancel := context.WithCancel(context.Background())
+		c := New(make([]int, 1000)).ToChannelWithBufferContext(ctx, 0)
+		<-c
+		cancel()
+		closed := make(chan struct{})
+		go func() {
+			for range c {
+			}
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			assert.Fail("the producer did not stop")
+		}
+	}
+	{ // ToChannelWithBufferContext sends every value
+		c := New([]int{1, 2, 3}).ToChannelWithBufferContext(context.Background(), 1)
+		assert.Equal([]int{1, 2, 3}, NewFromChannel(c).ToSlice())
+	}
+	{ // NewFromChannelContext
+		c := make(chan int, 2)
+		c <- 1
+		c <- 2
+		close(c)
+		actual, err := NewFromChannelContext(context.Background(), c)
+		assert.NoError(err)
+		assert.Equal([]int{1, 2}, actual.ToSlice())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		open := make(chan int, 1)
+		open <- 7
+		actual, err = NewFromChannelContext(ctx, open)
+		assert.ErrorIs(err, context.DeadlineExceeded)
+		assert.Equal([]int{7}, actual.ToSlice())
+	}
+}
+
+func Test_Async_PanicRecovery(t *testing.T) {
+	assert := assert.New(t)
+	explode := func(i int) int {
+		if i == 3 {
+			panic("boom")
+		}
+		return i * 2
+	}
+	{ // TryRunInAsync
+		actual, err := TryRunInAsync(New([]int{1, 2, 3, 4}), explode)
+		var panicErr *DelegatePanicError
+		assert.ErrorAs(err, &panicErr)
+		assert.Equal(2, panicErr.Index)
+		assert.Equal(3, panicErr.Value)
+		assert.Equal("boom", panicErr.Recovered)
+		assert.Contains(string(panicErr.Stack), "Test_Async_PanicRecovery")
+		assert.Equal([]int{2, 4, 0, 8}, actual)
+	}
+	{ // RunInAsync panics on the calling goroutine
+		assert.PanicsWithError("linq: delegate panicked on element 2 (3): boom", func() {
+			RunInAsync(New([]int{1, 2, 3, 4}), explode)
+		})
+	}
+	{ // RunInAsyncWithRoutineLimit runs every action, then panics on the calling goroutine
+		calls := atomic.Int32{}
+		assert.PanicsWithError("linq: delegate panicked on element 2 (3): boom", func() {
+			New([]int{1, 2, 3, 4, 5, 6}).RunInAsyncWithRoutineLimit(func(i int) {
+				calls.Add(1)
+				explode(i)
+			}, 1)
+		})
+		assert.Equal(int32(6), calls.Load())
+	}
+	{ // MapConcurrent returns the panic as an error and keeps the recovered error
+		errBoom := errors.New("boom")
+		_, err := MapConcurrentWithOptions(New([]int{1, 2, 3}), AsyncOptions{Limit: 2, Mode: CollectAll}, func(i int) (int, error) {
+			if i == 2 {
+				panic(errBoom)
+			}
+			if i == 3 {
+				return 0, errors.New("plain")
+			}
+			return i, nil
+		})
+		assert.ErrorIs(err, errBoom)
+		var panicErr *DelegatePanicError
+		assert.ErrorAs(err, &panicErr)
+		assert.Equal(1, panicErr.Index)
+		var indexed *IndexedError
+		assert.ErrorAs(err, &indexed)
+		assert.Equal(2, indexed.Index)
+	}
+	{ // a panic is not hidden by the cancellation of ctx
+		ctx, cancel := context.WithCancel(context.Background())
+		err := New([]int{1, 2}).RunInAsyncWithRoutineLimitContext(ctx, func(_ context.Context, i int) {
+			cancel()
+			panic("boom")
+		}, 1)
+		assert.ErrorIs(err, context.Canceled)
+		var panicErr *DelegatePanicError
+		assert.ErrorAs(err, &panicErr)
+	}
+}
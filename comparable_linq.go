@@ -0,0 +1,111 @@
+package linq
+
+// ComparableLinq provides hash based implementations of the following methods for comparable types:
+//   - Contains
+//   - ContainsAll
+//   - ContainsAny
+//   - Distinct
+//   - Union
+//   - Intersect
+//   - Except
+type ComparableLinq[T comparable] struct {
+	linq[T]
+}
+
+func NewComparableLinq[T comparable](items []T) ComparableLinq[T] {
+	return ComparableLinq[T]{linq[T]{items: items}}
+}
+
+// DistinctComparable returns distinct elements from a sequence by using the == operator to compare values.
+// It runs in linear time, unlike linq[T].Distinct which compares every pair of elements with reflect.DeepEqual.
+func DistinctComparable[T comparable](items Enumerable[T]) Linq[T] {
+	return NewComparableLinq(collect(items)).Distinct()
+}
+
+func (cl ComparableLinq[T]) set() map[T]struct{} {
+	return toSet(cl.items)
+}
+
+func toSet[T comparable](items []T) map[T]struct{} {
+	res := make(map[T]struct{}, len(items))
+	for _, elem := range items {
+		res[elem] = struct{}{}
+	}
+	return res
+}
+
+// Contains determines whether a sequence contains a specified element.
+func (cl ComparableLinq[T]) Contains(target T) bool {
+	for _, elem := range cl.items {
+		if elem == target {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAll determines whether a sequence contains every specified element.
+func (cl ComparableLinq[T]) ContainsAll(targets ...T) bool {
+	set := cl.set()
+	for _, target := range targets {
+		if _, ok := set[target]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny determines whether a sequence contains any of the specified elements.
+func (cl ComparableLinq[T]) ContainsAny(targets ...T) bool {
+	set := toSet(targets)
+	for _, elem := range cl.items {
+		if _, ok := set[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Distinct returns distinct elements from a sequence by using the == operator to compare values.
+func (cl ComparableLinq[T]) Distinct() Linq[T] {
+	res := []T{}
+	seen := make(map[T]struct{})
+	for _, elem := range cl.items {
+		if _, ok := seen[elem]; !ok {
+			seen[elem] = struct{}{}
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
+// Union produces the set union of two sequences, in the order the elements are first seen.
+func (cl ComparableLinq[T]) Union(other Linq[T]) Linq[T] {
+	return NewComparableLinq(append(cl.ToSlice(), other.ToSlice()...)).Distinct()
+}
+
+// Intersect produces the set intersection of two sequences, in the order the elements appear in the first sequence.
+func (cl ComparableLinq[T]) Intersect(other Linq[T]) Linq[T] {
+	res := []T{}
+	set := toSet(other.ToSlice())
+	for _, elem := range cl.items {
+		if _, ok := set[elem]; ok {
+			delete(set, elem)
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
+// Except produces the set difference of two sequences, in the order the elements appear in the first sequence.
+func (cl ComparableLinq[T]) Except(other Linq[T]) Linq[T] {
+	res := []T{}
+	set := toSet(other.ToSlice())
+	for _, elem := range cl.items {
+		if _, ok := set[elem]; !ok {
+			set[elem] = struct{}{}
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
@@ -0,0 +1,125 @@
+package linq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ComparableLinq_Methods(t *testing.T) {
+	assert := assert.New(t)
+	cl := NewComparableLinq([]int{1, 2, 3, 1, 5, 5, 2, 3, 8})
+	{ // Contains
+		assert.True(cl.Contains(5))
+		assert.False(cl.Contains(4))
+	}
+	{ // ContainsAll
+		assert.True(cl.ContainsAll(1, 8, 3))
+		assert.True(cl.ContainsAll())
+		assert.False(cl.ContainsAll(1, 4))
+	}
+	{ // ContainsAny
+		assert.True(cl.ContainsAny(4, 8))
+		assert.False(cl.ContainsAny(4, 6))
+	}
+	{ // Distinct
+		assert.Equal([]int{1, 2, 3, 5, 8}, cl.Distinct().ToSlice())
+	}
+	{ // DistinctComparable
+		actual := DistinctComparable(New([]string{"b", "a", "b", "c", "a"}))
+		assert.Equal([]string{"b", "a", "c"}, actual.ToSlice())
+	}
+	{ // Union
+		actual := cl.Union(New([]int{9, 8, 7, 9}))
+		assert.Equal([]int{1, 2, 3, 5, 8, 9, 7}, actual.ToSlice())
+	}
+	{ // Intersect
+		actual := cl.Intersect(New([]int{8, 3, 4, 3}))
+		assert.Equal([]int{3, 8}, actual.ToSlice())
+	}
+	{ // Except
+		actual := cl.Except(New([]int{2, 5}))
+		assert.Equal([]int{1, 3, 8}, actual.ToSlice())
+	}
+	{ // the linq[T] methods are still available
+		assert.Equal(9, cl.Length())
+		assert.Equal([]int{5, 5, 8}, cl.Where(func(i int) bool { return i > 3 }).ToSlice())
+	}
+}
+
+func benchmarkIDs(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i % (n / 2)
+	}
+	return res
+}
+
+// Distinct compares every pair of elements with reflect.DeepEqual (O(n²)),
+// DistinctComparable uses a map (O(n)).
+func Benchmark_Distinct(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		ids := New(benchmarkIDs(n))
+		b.Run(fmt.Sprintf("Linq/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ids.Distinct()
+			}
+		})
+		b.Run(fmt.Sprintf("Comparable/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				DistinctComparable(ids)
+			}
+		})
+	}
+	ids := New(benchmarkIDs(200_000))
+	b.Run("Comparable/200000", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DistinctComparable(ids)
+		}
+	})
+}
+
+func Benchmark_ContainsAll(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		ids := benchmarkIDs(n)
+		targets := ids[:n/10]
+		b.Run(fmt.Sprintf("Linq/%d", n), func(b *testing.B) {
+			l := New(ids)
+			for i := 0; i < b.N; i++ {
+				for _, target := range targets {
+					if !l.Contains(target) {
+						b.Fatal("missing target")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Comparable/%d", n), func(b *testing.B) {
+			cl := NewComparableLinq(ids)
+			for i := 0; i < b.N; i++ {
+				if !cl.ContainsAll(targets...) {
+					b.Fatal("missing target")
+				}
+			}
+		})
+	}
+}
+
+func Benchmark_Except(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		first := benchmarkIDs(n)
+		second := New(first[:n/4])
+		b.Run(fmt.Sprintf("Linq/%d", n), func(b *testing.B) {
+			l := New(first)
+			for i := 0; i < b.N; i++ {
+				l.Where(func(id int) bool { return !second.Contains(id) }).Distinct()
+			}
+		})
+		b.Run(fmt.Sprintf("Comparable/%d", n), func(b *testing.B) {
+			cl := NewComparableLinq(first)
+			for i := 0; i < b.N; i++ {
+				cl.Except(second)
+			}
+		})
+	}
+}
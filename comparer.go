@@ -0,0 +1,245 @@
+package linq
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EqualityComparer defines methods to support the comparison of elements for equality.
+// Elements that are equal according to Equals must return the same value from Hash.
+type EqualityComparer[T any] interface {
+	// Equals determines whether the specified elements are equal.
+	Equals(a, b T) bool
+	// Hash returns a hash code for the specified element.
+	Hash(t T) uint64
+}
+
+var comparerSeed = maphash.MakeSeed()
+
+type comparableComparer[T comparable] struct{}
+
+func (comparableComparer[T]) Equals(a, b T) bool {
+	return a == b
+}
+
+func (comparableComparer[T]) Hash(t T) uint64 {
+	return hashComparable(t)
+}
+
+func (comparableComparer[T]) mapKey(t T) any {
+	return t
+}
+
+// DefaultComparer returns an EqualityComparer that compares comparable values with the == operator.
+func DefaultComparer[T comparable]() EqualityComparer[T] {
+	return comparableComparer[T]{}
+}
+
+type deepEqualComparer[T any] struct{}
+
+func (deepEqualComparer[T]) Equals(a, b T) bool {
+	return equal(a, b)
+}
+
+// Hash returns the same value for every element since reflect.DeepEqual gives no hashing guarantee.
+func (deepEqualComparer[T]) Hash(T) uint64 {
+	return 0
+}
+
+// DeepEqualComparer returns an EqualityComparer that compares values with reflect.DeepEqual.
+// It is the comparer used by Contains, Distinct, ReplaceAll and Remove.
+// Every element has the same hash code, so hash based operations degrade to linear searches with it.
+func DeepEqualComparer[T any]() EqualityComparer[T] {
+	return deepEqualComparer[T]{}
+}
+
+type keyComparer[T any, K comparable] struct {
+	key func(T) K
+}
+
+func (c keyComparer[T, K]) Equals(a, b T) bool {
+	return c.key(a) == c.key(b)
+}
+
+func (c keyComparer[T, K]) Hash(t T) uint64 {
+	return hashComparable(c.key(t))
+}
+
+func (c keyComparer[T, K]) mapKey(t T) any {
+	return c.key(t)
+}
+
+// KeyComparer returns an EqualityComparer that compares elements by the key returned from the specified key selector function.
+func KeyComparer[T any, K comparable](keySelector func(T) K) EqualityComparer[T] {
+	return keyComparer[T, K]{key: keySelector}
+}
+
+type funcComparer[T any] struct {
+	equals func(a, b T) bool
+	hash   func(T) uint64
+}
+
+func (c funcComparer[T]) Equals(a, b T) bool {
+	return c.equals(a, b)
+}
+
+func (c funcComparer[T]) Hash(t T) uint64 {
+	return c.hash(t)
+}
+
+// NewEqualityComparer creates an EqualityComparer from the specified equality and hash functions.
+func NewEqualityComparer[T any](equals func(a, b T) bool, hash func(T) uint64) EqualityComparer[T] {
+	return funcComparer[T]{equals: equals, hash: hash}
+}
+
+type ignoreCaseComparer struct{}
+
+func (ignoreCaseComparer) Equals(a, b string) bool {
+	return foldCase(a) == foldCase(b)
+}
+
+func (ignoreCaseComparer) Hash(s string) uint64 {
+	return maphash.String(comparerSeed, foldCase(s))
+}
+
+// IgnoreCaseComparer returns an EqualityComparer that compares strings under Unicode case folding.
+func IgnoreCaseComparer() EqualityComparer[string] {
+	return ignoreCaseComparer{}
+}
+
+// foldCase maps every rune to the smallest rune of its case folding orbit,
+// so that two strings are equal under simple case folding if and only if their folded forms are equal.
+func foldCase(s string) string {
+	res := make([]byte, 0, len(s))
+	for _, r := range s {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		res = utf8.AppendRune(res, min)
+	}
+	return string(res)
+}
+
+// hashComparable returns a hash code for a comparable value, values that are equal according to the == operator have the same hash code.
+func hashComparable[T comparable](t T) uint64 {
+	var h maphash.Hash
+	h.SetSeed(comparerSeed)
+	writeComparable(&h, reflect.ValueOf(&t).Elem())
+	return h.Sum64()
+}
+
+func writeComparable(h *maphash.Hash, v reflect.Value) {
+	writeUint64 := func(u uint64) {
+		var b [8]byte
+		for i := range b {
+			b[i] = byte(u >> (8 * i))
+		}
+		h.Write(b[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // +0 and -0 are equal
+		}
+		writeUint64(math.Float64bits(f))
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint64(1)
+		} else {
+			writeUint64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			writeUint64(0)
+			return
+		}
+		h.WriteString(v.Elem().Type().String())
+		writeComparable(h, v.Elem())
+	case reflect.Array:
+		for i := range v.Len() {
+			writeComparable(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			writeComparable(h, v.Field(i))
+		}
+	}
+}
+
+// mapKeyer is implemented by the comparers whose equality is the == operator on a comparable key,
+// hashSet then stores the keys in a map instead of hashing them.
+type mapKeyer[T any] interface {
+	mapKey(t T) any
+}
+
+// hashSet is a set of elements bucketed by the hash code of an EqualityComparer.
+type hashSet[T any] struct {
+	comparer EqualityComparer[T]
+	buckets  map[uint64][]T
+	keyer    mapKeyer[T]
+	keys     map[any]struct{}
+}
+
+func newHashSet[T any](comparer EqualityComparer[T]) *hashSet[T] {
+	if keyer, ok := comparer.(mapKeyer[T]); ok {
+		return &hashSet[T]{comparer: comparer, keyer: keyer, keys: make(map[any]struct{})}
+	}
+	return &hashSet[T]{
+		comparer: comparer,
+		buckets:  make(map[uint64][]T),
+	}
+}
+
+// Add adds an element to the set and reports whether it was not already present.
+func (s *hashSet[T]) Add(t T) bool {
+	if s.keyer != nil {
+		key := s.keyer.mapKey(t)
+		if _, ok := s.keys[key]; ok {
+			return false
+		}
+		s.keys[key] = struct{}{}
+		return true
+	}
+	h := s.comparer.Hash(t)
+	for _, elem := range s.buckets[h] {
+		if s.comparer.Equals(elem, t) {
+			return false
+		}
+	}
+	s.buckets[h] = append(s.buckets[h], t)
+	return true
+}
+
+// Contains determines whether the set contains the specified element.
+func (s *hashSet[T]) Contains(t T) bool {
+	if s.keyer != nil {
+		_, ok := s.keys[s.keyer.mapKey(t)]
+		return ok
+	}
+	for _, elem := range s.buckets[s.comparer.Hash(t)] {
+		if s.comparer.Equals(elem, t) {
+			return true
+		}
+	}
+	return false
+}
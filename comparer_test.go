@@ -0,0 +1,120 @@
+package linq
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EqualityComparer(t *testing.T) {
+	assert := assert.New(t)
+	type user struct {
+		id   int
+		name string
+	}
+	users := New([]user{{1, "Ann"}, {2, "Jack"}, {1, "Annie"}, {3, "Ian"}})
+	byID := KeyComparer(func(u user) int { return u.id })
+	{ // DefaultComparer
+		c := DefaultComparer[string]()
+		assert.True(c.Equals("a", "a"))
+		assert.False(c.Equals("a", "b"))
+		assert.Equal(c.Hash("a"), c.Hash("a"))
+	}
+	{ // IgnoreCaseComparer
+		c := IgnoreCaseComparer()
+		assert.True(c.Equals("Hello", "hELLO"))
+		assert.True(c.Equals("ſ", "S"))
+		assert.False(c.Equals("Hello", "World"))
+		assert.Equal(c.Hash("Straße"), c.Hash("STRAßE"))
+	}
+	{ // ContainsBy
+		assert.True(users.ContainsBy(user{id: 3}, byID))
+		assert.False(users.ContainsBy(user{id: 4}, byID))
+		assert.False(users.Contains(user{id: 3}))
+	}
+	{ // DistinctBy
+		actual := users.DistinctBy(byID).ToSlice()
+		assert.Equal([]user{{1, "Ann"}, {2, "Jack"}, {3, "Ian"}}, actual)
+	}
+	{ // DistinctBy with IgnoreCaseComparer
+		actual := New([]string{"Go", "go", "LINQ", "Linq", "gO"}).DistinctBy(IgnoreCaseComparer()).ToSlice()
+		assert.Equal([]string{"Go", "LINQ"}, actual)
+	}
+	{ // DistinctBy with DefaultComparer
+		actual := New([]int{1, 2, 3, 1, 5, 5, 2, 3, 8}).DistinctBy(DefaultComparer[int]()).ToSlice()
+		assert.Equal([]int{1, 2, 3, 5, 8}, actual)
+	}
+	{ // ReplaceAllBy
+		actual := New([]string{"a", "B", "b", "c"}).ReplaceAllBy("b", "x", IgnoreCaseComparer()).ToSlice()
+		assert.Equal([]string{"a", "x", "x", "c"}, actual)
+	}
+	{ // RemoveBy
+		actual := users.Clone()
+		assert.True(actual.RemoveBy(user{id: 1}, byID))
+		assert.Equal([]user{{2, "Jack"}, {1, "Annie"}, {3, "Ian"}}, actual.ToSlice())
+		assert.False(actual.RemoveBy(user{id: 9}, byID))
+	}
+	{ // NewEqualityComparer
+		byLength := NewEqualityComparer(
+			func(a, b string) bool { return len(a) == len(b) },
+			func(s string) uint64 { return uint64(len(s)) },
+		)
+		actual := New(strings.Fields("a bb c dd eee")).DistinctBy(byLength).ToSlice()
+		assert.Equal([]string{"a", "bb", "eee"}, actual)
+	}
+}
+
+// Values that are equal according to the == operator must have the same hash code.
+func Test_DefaultComparer_Hash(t *testing.T) {
+	assert := assert.New(t)
+	type key struct {
+		id    int
+		name  string
+		score float64
+		tag   any
+		ptr   *int
+		pair  [2]uint8
+	}
+	n := 1
+	a := key{id: 1, name: "a", score: 0, tag: "x", ptr: &n, pair: [2]uint8{1, 2}}
+	b := key{id: 1, name: "a", score: math.Copysign(0, -1), tag: "x", ptr: &n, pair: [2]uint8{1, 2}}
+	c := DefaultComparer[key]()
+	assert.True(c.Equals(a, b))
+	assert.Equal(c.Hash(a), c.Hash(b))
+
+	b.pair[1] = 3
+	assert.NotEqual(c.Hash(a), c.Hash(b))
+	b.pair[1], b.tag = 2, 1
+	assert.NotEqual(c.Hash(a), c.Hash(b))
+
+	boxed := DefaultComparer[any]()
+	assert.Equal(boxed.Hash(1), boxed.Hash(1))
+	assert.NotEqual(boxed.Hash(1), boxed.Hash(int64(1)))
+	assert.Equal(boxed.Hash(nil), boxed.Hash(nil))
+
+	byName := KeyComparer(func(k key) string { return k.name })
+	assert.Equal(byName.Hash(a), byName.Hash(key{name: "a"}))
+}
+
+// hashSet keys the elements directly for the comparers based on the == operator.
+func Test_HashSet(t *testing.T) {
+	assert := assert.New(t)
+	for _, comparer := range []EqualityComparer[float64]{
+		DefaultComparer[float64](),
+		NewEqualityComparer(func(a, b float64) bool { return a == b }, hashComparable[float64]),
+	} {
+		s := newHashSet(comparer)
+		assert.True(s.Add(1))
+		assert.False(s.Add(1))
+		assert.True(s.Add(math.NaN()))
+		assert.True(s.Add(math.NaN()))
+		assert.True(s.Add(math.Copysign(0, -1)))
+		assert.False(s.Add(0))
+		assert.True(s.Contains(1))
+		assert.True(s.Contains(0))
+		assert.False(s.Contains(2))
+		assert.False(s.Contains(math.NaN()))
+	}
+}
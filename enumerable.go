@@ -0,0 +1,86 @@
+package linq
+
+import "iter"
+
+// Enumerable is a source of elements accepted by the package-level functions.
+// Linq[T] and Query[T] are Enumerable, and Slice, Chan, Seq and Entries adapt the built-in iterables without copying them.
+type Enumerable[T any] interface {
+	// Values returns an iterator over the elements of the source.
+	Values() iter.Seq[T]
+}
+
+type sliceSource[T any] []T
+
+func (s sliceSource[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, elem := range s {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Slice adapts a slice to an Enumerable.
+func Slice[T any](s []T) Enumerable[T] {
+	return sliceSource[T](s)
+}
+
+type chanSource[T any] <-chan T
+
+func (c chanSource[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range c {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Chan adapts a channel to an Enumerable, enumerating it receives from the channel until it is closed.
+// ! Make sure to close the channel when you are done sending elements to it.
+func Chan[T any](c <-chan T) Enumerable[T] {
+	return chanSource[T](c)
+}
+
+type seqSource[T any] iter.Seq[T]
+
+func (s seqSource[T]) Values() iter.Seq[T] {
+	return iter.Seq[T](s)
+}
+
+// Seq adapts an iterator to an Enumerable.
+func Seq[T any](seq iter.Seq[T]) Enumerable[T] {
+	return seqSource[T](seq)
+}
+
+type mapSource[K comparable, V any] map[K]V
+
+func (m mapSource[K, V]) Values() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range m {
+			if !yield(NewPair(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Entries adapts a map to an Enumerable of its key-value pairs, the iteration order is not specified.
+func Entries[K comparable, V any](m map[K]V) Enumerable[Pair[K, V]] {
+	return mapSource[K, V](m)
+}
+
+// collect creates a slice from the elements of an Enumerable.
+// The slice of a Slice adapter is returned as is, so the result must not be modified.
+func collect[T any](items Enumerable[T]) []T {
+	if s, ok := items.(sliceSource[T]); ok {
+		return s
+	}
+	res := []T{}
+	for elem := range items.Values() {
+		res = append(res, elem)
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+package linq
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Enumerable_Sources(t *testing.T) {
+	assert := assert.New(t)
+	double := func(i int) int { return i * 2 }
+	{ // Slice
+		assert.Equal([]int{2, 4, 6}, Select(Slice([]int{1, 2, 3}), double).ToSlice())
+		assert.Empty(Select(Slice([]int(nil)), double).ToSlice())
+	}
+	{ // Linq
+		assert.Equal([]int{4, 6}, Select(New([]int{1, 2, 3}).Skip(1), double).ToSlice())
+	}
+	{ // Query
+		q := NewQuery([]int{1, 2, 3, 4}).Where(func(i int) bool { return i%2 == 0 })
+		assert.Equal([]int{4, 8}, Select(q, double).ToSlice())
+	}
+	{ // Chan
+		c := make(chan int, 3)
+		c <- 1
+		c <- 2
+		c <- 3
+		close(c)
+		assert.Equal([][]int{{1, 2}, {3}}, Chunk(Chan(c), 2).ToSlice())
+	}
+	{ // Seq
+		actual := Zip(Seq(slices.Values([]int{1, 2, 3})), Slice([]string{"a", "b"}), func(i int, s string) string {
+			return strconv.Itoa(i) + s
+		})
+		assert.Equal([]string{"1a", "2b"}, actual.ToSlice())
+	}
+	{ // Entries
+		m := map[string]int{"a": 1, "b": 2, "c": 3}
+		keys := Select(Entries(m), func(p Pair[string, int]) string { return p.First }).ToSlice()
+		assert.ElementsMatch(slices.Collect(maps.Keys(m)), keys)
+		assert.Equal(6, Aggregate(Entries(m), 0, func(acc int, p Pair[string, int]) int { return acc + p.Second }))
+	}
+}
+
+// Zip stops pulling from the longer source once the shorter one is exhausted.
+func Test_Enumerable_StopsEarly(t *testing.T) {
+	assert := assert.New(t)
+	pulled := 0
+	naturals := Seq(func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	actual := ZipPairs(naturals, Slice([]string{"a", "b", "c"}))
+	assert.Equal([]Pair[int, string]{{0, "a"}, {1, "b"}, {2, "c"}}, actual.ToSlice())
+	assert.LessOrEqual(pulled, 4)
+}
@@ -0,0 +1,21 @@
+package linq
+
+import "errors"
+
+// Sentinel errors returned by the error-returning (Try) methods, use errors.Is to check them.
+var (
+	// ErrEmptySequence is returned when an operation requires at least one element but the sequence is empty.
+	ErrEmptySequence = errors.New("linq: empty sequence")
+	// ErrNoMatch is returned when no element satisfies the specified condition.
+	ErrNoMatch = errors.New("linq: no element satisfies the condition")
+	// ErrNotUnique is returned when more than one element satisfies the specified condition.
+	ErrNotUnique = errors.New("linq: more than one element satisfies the condition")
+	// ErrOutOfRange is returned when an index or a count is out of range.
+	ErrOutOfRange = errors.New("linq: argument out of range")
+	// ErrInsufficientData is returned when a statistic needs more elements than the sequence contains.
+	ErrInsufficientData = errors.New("linq: insufficient data")
+	// ErrOverflow is returned when an arithmetic operation overflows the result type.
+	ErrOverflow = errors.New("linq: arithmetic overflow")
+	// ErrNotFinite is returned when an operation requires finite values but the sequence contains an infinity or NaN.
+	ErrNotFinite = errors.New("linq: value is not finite")
+)
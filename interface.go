@@ -1,20 +1,31 @@
 package linq
 
+import (
+	"context"
+	"iter"
+)
+
 type Linq[T any] interface {
 	// All determines whether all elements of a sequence satisfy a condition.
 	All(predicate func(T) bool) bool
 	// Any determines whether any element of a sequence satisfies a condition.
 	Any(predicate func(T) bool) bool
+	// AsQuery returns a Query that lazily enumerates the elements of linq[T].
+	AsQuery() Query[T]
 	// Append appends a value to the end of the sequence.
 	Append(t ...T) Linq[T]
 	// Clone returns a copy of linq[T]
 	Clone() Linq[T]
 	// Contains determines whether a sequence contains a specified element.
 	Contains(target T) bool
+	// ContainsBy determines whether a sequence contains a specified element by using the specified EqualityComparer.
+	ContainsBy(target T, comparer EqualityComparer[T]) bool
 	// Count returns a number that represents how many elements in the specified sequence satisfy a condition.
 	Count(predicate func(T) bool) int
 	// Distinct returns distinct elements from a sequence by using the default equality comparer to compare values.
 	Distinct() Linq[T]
+	// DistinctBy returns distinct elements from a sequence by using the specified EqualityComparer to compare values.
+	DistinctBy(comparer EqualityComparer[T]) Linq[T]
 	// ElementAt returns the element at a specified index in a sequence.
 	// ! this method panics when index is out of range.
 	ElementAt(index int) T
@@ -22,6 +33,8 @@ type Linq[T any] interface {
 	ElementAtOrDefault(index int) T
 	// Empty returns an empty linq[T] that has the specified type argument.
 	Empty() Linq[T]
+	// Except produces the set difference of two sequences by using the default equality comparer, in the order the elements appear in the first sequence.
+	Except(other Linq[T]) Linq[T]
 	// Exists determines whether the linq[T] contains elements that match the conditions defined by the specified predicate.
 	Exists(predicate func(T) bool) bool
 	// Find Searches for an element that matches the conditions defined by the specified predicate, and returns the first occurrence within the entire linq[T].
@@ -41,22 +54,33 @@ type Linq[T any] interface {
 	FirstOrDefault(predicate func(T) bool) T
 	// ForEach performs the specified action on each element of the linq[T].
 	ForEach(callBack func(T))
+	// Indexed returns an iterator over the index-element pairs of linq[T].
+	Indexed() iter.Seq2[int, T]
+	// Intersect produces the set intersection of two sequences by using the default equality comparer, in the order the elements appear in the first sequence.
+	Intersect(other Linq[T]) Linq[T]
 	// Last returns the last element of a sequence.
 	// ! this method panics when no element is found.
 	Last(predicate func(T) bool) T
 	// LastOrDefault returns the last element of a sequence, or a specified default value if the sequence contains no elements.
 	LastOrDefault(predicate func(T) bool) T
 	// OrderBy sorts the elements of a sequence in ascending order according to a key.
-	OrderBy(comparer func(T) int) Linq[T]
+	OrderBy(comparer func(T) int) OrderedLinq[T]
 	// OrderByDescending sorts the elements of a sequence in descending order according to a key.
-	OrderByDescending(comparer func(T) int) Linq[T]
+	OrderByDescending(comparer func(T) int) OrderedLinq[T]
+	// OrderByFunc sorts the elements of a sequence in ascending order according to a comparison function.
+	OrderByFunc(compare func(a, b T) int) OrderedLinq[T]
 	// Prepend adds a value to the beginning of the sequence.
 	Prepend(t ...T) Linq[T]
 	// ReplaceAll replaces old values by new values
 	ReplaceAll(oldValue T, newValue T) Linq[T]
+	// ReplaceAllBy replaces old values by new values by using the specified EqualityComparer to find the old values.
+	ReplaceAllBy(oldValue T, newValue T, comparer EqualityComparer[T]) Linq[T]
 	// Reverse inverts the order of the elements in a sequence.
 	Reverse() Linq[T]
+	// RunInAsyncWithRoutineLimit performs the specified action on each element of linq[T] on at most limit goroutines, and returns once every action has returned.
 	RunInAsyncWithRoutineLimit(delegate func(T), limit int)
+	// RunInAsyncWithRoutineLimitContext performs the specified action on each element of linq[T] on at most limit goroutines, and stops starting actions once ctx is done.
+	RunInAsyncWithRoutineLimitContext(ctx context.Context, delegate func(context.Context, T), limit int) error
 	// Single returns the only element of a sequence that satisfies a specified condition, and panics if more than one such element exists.
 	Single(predicate func(T) bool) T
 	// SingleOrDefault returns the only element of a sequence, or a default value of T if the sequence is empty.
@@ -67,28 +91,64 @@ type Linq[T any] interface {
 	// SkipLast returns a new enumerable collection that contains the elements from source with the last count elements of the source collection omitted.
 	// ! this method panics when count is out of range.
 	SkipLast(count int) Linq[T]
-	// SkipWhile bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements. The element's index is used in the logic of the predicate function.
+	// SkipWhile bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements.
 	SkipWhile(predicate func(T) bool) Linq[T]
+	// SkipWhileIndexed bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements. The element's index is used in the logic of the predicate function.
+	SkipWhileIndexed(predicate func(int, T) bool) Linq[T]
 	// Take returns a specified number of contiguous elements from the start of a sequence.
 	// ! this method panics when count is out of range.
 	Take(count int) Linq[T]
 	// TakeLast returns a new enumerable collection that contains the last count elements from source.
 	// ! this method panics when count is out of range.
 	TakeLast(count int) Linq[T]
-	// TakeWhile returns elements from a sequence as long as a specified condition is true. The element's index is used in the logic of the predicate function.
+	// TakeWhile returns elements from a sequence as long as a specified condition is true.
 	TakeWhile(predicate func(T) bool) Linq[T]
+	// TakeWhileIndexed returns elements from a sequence as long as a specified condition is true. The element's index is used in the logic of the predicate function.
+	TakeWhileIndexed(predicate func(int, T) bool) Linq[T]
 	// ToChannel creates a channel with values in linq[T]
 	ToChannel() <-chan T
 	// ToChannelWithBuffer creates a channel with values in linq[T] with specified buffer. (async method)
 	ToChannelWithBuffer(buffer int) <-chan T
+	// ToChannelWithBufferContext creates a channel with values in linq[T] with specified buffer, the producer stops once ctx is done. (async method)
+	ToChannelWithBufferContext(ctx context.Context, buffer int) <-chan T
 	// Creates a map[interface{}]T from an linq[T] according to a specified key selector function.
 	ToMapWithKey(keySelector func(T) interface{}) map[interface{}]T
 	// Creates a map[interface{}]interface from an linq[T] according to a specified key selector function.
 	ToMapWithKeyValue(keySelector func(T) interface{}, valueSelector func(T) interface{}) map[interface{}]interface{}
 	// ToSlice creates a slice from a linq[T].
 	ToSlice() []T
+	// TryElementAt returns the element at a specified index in a sequence.
+	// It returns ErrOutOfRange when index is out of range.
+	TryElementAt(index int) (T, error)
+	// TryFirst returns the first element in a sequence that satisfies a specified condition.
+	// It returns ErrEmptySequence when the sequence is empty and ErrNoMatch when no element satisfies the condition.
+	TryFirst(predicate func(T) bool) (T, error)
+	// TryLast returns the last element in a sequence that satisfies a specified condition.
+	// It returns ErrEmptySequence when the sequence is empty and ErrNoMatch when no element satisfies the condition.
+	TryLast(predicate func(T) bool) (T, error)
+	// TrySingle returns the only element of a sequence that satisfies a specified condition.
+	// It returns ErrEmptySequence, ErrNoMatch or ErrNotUnique when there is not exactly one such element.
+	TrySingle(predicate func(T) bool) (T, error)
+	// TrySkip bypasses a specified number of elements in a sequence and then returns the remaining elements.
+	// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+	TrySkip(count int) (Linq[T], error)
+	// TrySkipLast returns a new enumerable collection that contains the elements from source with the last count elements of the source collection omitted.
+	// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+	TrySkipLast(count int) (Linq[T], error)
+	// TryTake returns a specified number of contiguous elements from the start of a sequence.
+	// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+	TryTake(count int) (Linq[T], error)
+	// TryTakeLast returns a new enumerable collection that contains the last count elements from source.
+	// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+	TryTakeLast(count int) (Linq[T], error)
+	// Union produces the set union of two sequences by using the default equality comparer, in the order the elements are first seen.
+	Union(other Linq[T]) Linq[T]
+	// Values returns an iterator over the elements of linq[T].
+	Values() iter.Seq[T]
 	// Where filters a sequence of values based on a predicate.
 	Where(predicate func(T) bool) Linq[T]
+	// WhereIndexed filters a sequence of values based on a predicate. Each element's index is used in the logic of the predicate function.
+	WhereIndexed(predicate func(int, T) bool) Linq[T]
 	// Length returns the number of items in the linq[T] collection.
 	Length() int
 
@@ -100,6 +160,8 @@ type Linq[T any] interface {
 	AddRange(collection []T)
 	// Remove removes the first occurrence of a specific object from the linq[T].
 	Remove(item T) bool
+	// RemoveBy removes the first occurrence of a specific object from the linq[T] by using the specified EqualityComparer.
+	RemoveBy(item T, comparer EqualityComparer[T]) bool
 	// RemoveAll removes all the elements that match the conditions defined by the specified predicate.
 	RemoveAll(predicate func(T) bool) int
 	// RemoveAt removes the element at the specified index of the linq[T].
@@ -108,4 +170,8 @@ type Linq[T any] interface {
 	RemoveRange(index int, count int) error
 	// Clear removes all elements from the linq[T].
 	Clear()
+	// Sort sorts the elements of the linq[T] in place according to a comparison function, the sort is stable.
+	Sort(compare func(a, b T) int)
+	// SortBy sorts the elements of the linq[T] in place in ascending order according to a key, the sort is stable.
+	SortBy(comparer func(T) int)
 }
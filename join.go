@@ -0,0 +1,58 @@
+package linq
+
+// Join correlates the elements of two sequences based on matching keys.
+// For every outer element, the result selector is called once per matching inner element, in the order of the outer and then the inner sequence.
+func Join[O, I any, K comparable, R any](outer Enumerable[O], inner Enumerable[I], outerKeySelector func(O) K, innerKeySelector func(I) K, resultSelector func(O, I) R) Linq[R] {
+	lookup := joinLookup(inner, innerKeySelector)
+	res := []R{}
+	for o := range outer.Values() {
+		for _, i := range lookup[outerKeySelector(o)] {
+			res = append(res, resultSelector(o, i))
+		}
+	}
+	return New(res)
+}
+
+// GroupJoin correlates the elements of two sequences based on matching keys and groups the results.
+// The result selector is called once per outer element with all the matching inner elements, which may be empty.
+func GroupJoin[O, I any, K comparable, R any](outer Enumerable[O], inner Enumerable[I], outerKeySelector func(O) K, innerKeySelector func(I) K, resultSelector func(O, Linq[I]) R) Linq[R] {
+	lookup := joinLookup(inner, innerKeySelector)
+	res := []R{}
+	for o := range outer.Values() {
+		matches := lookup[outerKeySelector(o)]
+		if matches == nil {
+			matches = []I{}
+		}
+		res = append(res, resultSelector(o, New(matches)))
+	}
+	return New(res)
+}
+
+// LeftJoin correlates the elements of two sequences based on matching keys, keeping the outer elements without a match.
+// The result selector reports whether the inner element is a match: for an outer element without a matching inner element,
+// it is called once with the default value of I and false, so a missing inner element can be told apart from a zero-valued one.
+func LeftJoin[O, I any, K comparable, R any](outer Enumerable[O], inner Enumerable[I], outerKeySelector func(O) K, innerKeySelector func(I) K, resultSelector func(o O, i I, matched bool) R) Linq[R] {
+	lookup := joinLookup(inner, innerKeySelector)
+	res := []R{}
+	for o := range outer.Values() {
+		matches, ok := lookup[outerKeySelector(o)]
+		if !ok {
+			var defaultValue I
+			res = append(res, resultSelector(o, defaultValue, false))
+			continue
+		}
+		for _, i := range matches {
+			res = append(res, resultSelector(o, i, true))
+		}
+	}
+	return New(res)
+}
+
+func joinLookup[I any, K comparable](inner Enumerable[I], keySelector func(I) K) map[K][]I {
+	res := make(map[K][]I)
+	for i := range inner.Values() {
+		key := keySelector(i)
+		res[key] = append(res[key], i)
+	}
+	return res
+}
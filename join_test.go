@@ -0,0 +1,53 @@
+package linq
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Join_Functions(t *testing.T) {
+	assert := assert.New(t)
+	type user struct {
+		id   int
+		name string
+	}
+	type order struct {
+		userID int
+		item   string
+	}
+	users := New([]user{{1, "Ann"}, {2, "Jack"}, {3, "Ian"}})
+	orders := New([]order{{3, "book"}, {1, "pen"}, {3, "lamp"}, {4, "desk"}})
+	userID := func(u user) int { return u.id }
+	orderUserID := func(o order) int { return o.userID }
+	{ // Join
+		actual := Join(users, orders, userID, orderUserID, func(u user, o order) string { return u.name + ":" + o.item })
+		assert.Equal([]string{"Ann:pen", "Ian:book", "Ian:lamp"}, actual.ToSlice())
+	}
+	{ // GroupJoin
+		actual := GroupJoin(users, orders, userID, orderUserID, func(u user, os Linq[order]) int { return os.Length() })
+		assert.Equal([]int{1, 0, 2}, actual.ToSlice())
+	}
+	{ // LeftJoin
+		actual := LeftJoin(users, orders, userID, orderUserID, func(u user, o order, matched bool) string {
+			if !matched {
+				return u.name + ":-"
+			}
+			return u.name + ":" + o.item
+		})
+		assert.Equal([]string{"Ann:pen", "Jack:-", "Ian:book", "Ian:lamp"}, actual.ToSlice())
+	}
+	{ // LeftJoin tells a zero-valued match apart from a missing one
+		counts := New([]int{0, 3})
+		actual := LeftJoin(New([]string{"a", "b", "c"}), counts,
+			func(s string) int { return int(s[0] - 'a') },
+			func(i int) int { return i },
+			func(s string, i int, matched bool) string { return s + ":" + strconv.FormatBool(matched) })
+		assert.Equal([]string{"a:true", "b:false", "c:false"}, actual.ToSlice())
+	}
+	{ // empty inner sequence
+		actual := Join(users, New([]order{}), userID, orderUserID, func(u user, o order) string { return u.name })
+		assert.Empty(actual.ToSlice())
+	}
+}
@@ -1,9 +1,10 @@
 package linq
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"reflect"
-	"sort"
 
 	"golang.org/x/exp/constraints"
 )
@@ -13,7 +14,7 @@ func equal[T any](a, b T) bool {
 }
 
 // linq simulates C# System.Linq Enumerable methods and System.Collections.Generic List methods.
-// Methods of linq will panic when something goes wrong.
+// Methods of linq will panic when something goes wrong, use the Try methods to get an error instead.
 type linq[T any] struct {
 	items []T
 }
@@ -44,10 +45,50 @@ func NewFromChannel[T any](c <-chan T) Linq[T] {
 	return New(res)
 }
 
+// linq constructor
+// It receives from the channel until the channel is closed or ctx is done, in the latter case the values received so far are returned with ctx.Err().
+func NewFromChannelContext[T any](ctx context.Context, c <-chan T) (Linq[T], error) {
+	res := make([]T, 0)
+	for {
+		select {
+		case v, ok := <-c:
+			if !ok {
+				return New(res), nil
+			}
+			res = append(res, v)
+		case <-ctx.Done():
+			return New(res), ctx.Err()
+		}
+	}
+}
+
+// linq constructor
+func FromSeq[T any](seq iter.Seq[T]) Linq[T] {
+	res := make([]T, 0)
+	for v := range seq {
+		res = append(res, v)
+	}
+	return New(res)
+}
+
+// linq constructor
+func FromSeq2[K, V, T any](seq iter.Seq2[K, V], delegate func(K, V) T) Linq[T] {
+	res := make([]T, 0)
+	for k, v := range seq {
+		res = append(res, delegate(k, v))
+	}
+	return New(res)
+}
+
 // Contains determines whether a sequence contains a specified element.
 func (l linq[T]) Contains(target T) bool {
+	return l.ContainsBy(target, DeepEqualComparer[T]())
+}
+
+// ContainsBy determines whether a sequence contains a specified element by using the specified EqualityComparer.
+func (l linq[T]) ContainsBy(target T, comparer EqualityComparer[T]) bool {
 	for _, elem := range l.items {
-		if equal(elem, target) {
+		if comparer.Equals(elem, target) {
 			return true
 		}
 	}
@@ -67,13 +108,19 @@ func (l linq[T]) Count(predicate func(T) bool) int {
 
 // Distinct returns distinct elements from a sequence by using the default equality comparer to compare values.
 func (l linq[T]) Distinct() Linq[T] {
-	res := l.Empty()
+	return l.DistinctBy(DeepEqualComparer[T]())
+}
+
+// DistinctBy returns distinct elements from a sequence by using the specified EqualityComparer to compare values.
+func (l linq[T]) DistinctBy(comparer EqualityComparer[T]) Linq[T] {
+	res := []T{}
+	set := newHashSet(comparer)
 	for _, elem := range l.items {
-		if !res.Contains(elem) {
-			res = res.Append(elem)
+		if set.Add(elem) {
+			res = append(res, elem)
 		}
 	}
-	return res
+	return New(res)
 }
 
 // Any determines whether any element of a sequence satisfies a condition.
@@ -117,6 +164,16 @@ func (l linq[T]) ElementAt(index int) T {
 	return l.items[index]
 }
 
+// TryElementAt returns the element at a specified index in a sequence.
+// It returns ErrOutOfRange when index is out of range.
+func (l linq[T]) TryElementAt(index int) (T, error) {
+	var defaultValue T
+	if index < 0 || index >= len(l.items) {
+		return defaultValue, ErrOutOfRange
+	}
+	return l.items[index], nil
+}
+
 // ElementAtOrDefault returns the element at a specified index in a sequence or a default value if the index is out of range.
 func (l linq[T]) ElementAtOrDefault(index int) T {
 	var defaultValue T
@@ -145,6 +202,21 @@ func (l linq[T]) First(predicate func(T) bool) T {
 	panic("linq: First() no match element in the slice")
 }
 
+// TryFirst returns the first element in a sequence that satisfies a specified condition.
+// It returns ErrEmptySequence when the sequence is empty and ErrNoMatch when no element satisfies the condition.
+func (l linq[T]) TryFirst(predicate func(T) bool) (T, error) {
+	var defaultValue T
+	if len(l.items) == 0 {
+		return defaultValue, ErrEmptySequence
+	}
+	for _, elem := range l.items {
+		if predicate(elem) {
+			return elem, nil
+		}
+	}
+	return defaultValue, ErrNoMatch
+}
+
 // FirstOrDefault returns the first element of a sequence, or a default value if the sequence contains no elements.
 func (l linq[T]) FirstOrDefault(predicate func(T) bool) T {
 	var defaultValue T
@@ -173,6 +245,21 @@ func (l linq[T]) Last(predicate func(T) bool) T {
 	panic("linq: Last() no match element in the slice")
 }
 
+// TryLast returns the last element in a sequence that satisfies a specified condition.
+// It returns ErrEmptySequence when the sequence is empty and ErrNoMatch when no element satisfies the condition.
+func (l linq[T]) TryLast(predicate func(T) bool) (T, error) {
+	var defaultValue T
+	if len(l.items) == 0 {
+		return defaultValue, ErrEmptySequence
+	}
+	for i := len(l.items) - 1; i >= 0; i-- {
+		if predicate(l.items[i]) {
+			return l.items[i], nil
+		}
+	}
+	return defaultValue, ErrNoMatch
+}
+
 // LastOrDefault returns the last element of a sequence, or a specified default value if the sequence contains no elements.
 func (l linq[T]) LastOrDefault(predicate func(T) bool) T {
 	var defaultValue T
@@ -195,6 +282,31 @@ func (l linq[T]) Single(predicate func(T) bool) T {
 	panic("linq: Single() eligible data count is not unique")
 }
 
+// TrySingle returns the only element of a sequence that satisfies a specified condition.
+// It returns ErrEmptySequence when the sequence is empty, ErrNoMatch when no element satisfies the condition
+// and ErrNotUnique when more than one element satisfies the condition.
+func (l linq[T]) TrySingle(predicate func(T) bool) (T, error) {
+	var res T
+	if len(l.items) == 0 {
+		return res, ErrEmptySequence
+	}
+	var found bool
+	for _, elem := range l.items {
+		if !predicate(elem) {
+			continue
+		}
+		if found {
+			var defaultValue T
+			return defaultValue, ErrNotUnique
+		}
+		res, found = elem, true
+	}
+	if !found {
+		return res, ErrNoMatch
+	}
+	return res, nil
+}
+
 // SingleOrDefault returns the only element of a sequence, or a default value of T if the sequence is empty.
 func (l linq[T]) SingleOrDefault(predicate func(T) bool) T {
 	var defaultValue T
@@ -215,6 +327,17 @@ func (l linq[T]) Where(predicate func(T) bool) Linq[T] {
 	return New(res)
 }
 
+// WhereIndexed filters a sequence of values based on a predicate. Each element's index is used in the logic of the predicate function.
+func (l linq[T]) WhereIndexed(predicate func(int, T) bool) Linq[T] {
+	res := []T{}
+	for i, elem := range l.items {
+		if predicate(i, elem) {
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
 // Reverse inverts the order of the elements in a sequence.
 func (l linq[T]) Reverse() Linq[T] {
 	res := make([]T, len(l.items))
@@ -237,7 +360,18 @@ func (l linq[T]) Take(count int) Linq[T] {
 	return New(res)
 }
 
-// TakeWhile returns elements from a sequence as long as a specified condition is true. The element's index is used in the logic of the predicate function.
+// TryTake returns a specified number of contiguous elements from the start of a sequence.
+// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+func (l linq[T]) TryTake(count int) (Linq[T], error) {
+	if count < 0 || count > len(l.items) {
+		return nil, ErrOutOfRange
+	}
+	res := make([]T, count)
+	copy(res, l.items)
+	return New(res), nil
+}
+
+// TakeWhile returns elements from a sequence as long as a specified condition is true.
 func (l linq[T]) TakeWhile(predicate func(T) bool) Linq[T] {
 	res := []T{}
 	for i := 0; i < len(l.items); i++ {
@@ -250,6 +384,18 @@ func (l linq[T]) TakeWhile(predicate func(T) bool) Linq[T] {
 	return New(res)
 }
 
+// TakeWhileIndexed returns elements from a sequence as long as a specified condition is true. The element's index is used in the logic of the predicate function.
+func (l linq[T]) TakeWhileIndexed(predicate func(int, T) bool) Linq[T] {
+	res := []T{}
+	for i := 0; i < len(l.items); i++ {
+		if !predicate(i, l.items[i]) {
+			break
+		}
+		res = append(res, l.items[i])
+	}
+	return New(res)
+}
+
 // TakeLast returns a new enumerable collection that contains the last count elements from source.
 // ! this method panics when count is out of range.
 func (l linq[T]) TakeLast(count int) Linq[T] {
@@ -259,6 +405,12 @@ func (l linq[T]) TakeLast(count int) Linq[T] {
 	return l.Skip(len(l.items) - count)
 }
 
+// TryTakeLast returns a new enumerable collection that contains the last count elements from source.
+// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+func (l linq[T]) TryTakeLast(count int) (Linq[T], error) {
+	return l.TrySkip(len(l.items) - count)
+}
+
 // Skip bypasses a specified number of elements in a sequence and then returns the remaining elements.
 // ! this method panics when count is out of range.
 func (l linq[T]) Skip(count int) Linq[T] {
@@ -268,7 +420,16 @@ func (l linq[T]) Skip(count int) Linq[T] {
 	return New(l.items[count:])
 }
 
-// SkipWhile bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements. The element's index is used in the logic of the predicate function.
+// TrySkip bypasses a specified number of elements in a sequence and then returns the remaining elements.
+// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+func (l linq[T]) TrySkip(count int) (Linq[T], error) {
+	if count < 0 || count > len(l.items) {
+		return nil, ErrOutOfRange
+	}
+	return New(l.items[count:]), nil
+}
+
+// SkipWhile bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements.
 func (l linq[T]) SkipWhile(predicate func(T) bool) Linq[T] {
 	for i := 0; i < len(l.items); i++ {
 		if predicate(l.items[i]) {
@@ -280,6 +441,16 @@ func (l linq[T]) SkipWhile(predicate func(T) bool) Linq[T] {
 	return l.Empty()
 }
 
+// SkipWhileIndexed bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements. The element's index is used in the logic of the predicate function.
+func (l linq[T]) SkipWhileIndexed(predicate func(int, T) bool) Linq[T] {
+	for i := 0; i < len(l.items); i++ {
+		if !predicate(i, l.items[i]) {
+			return New(l.items[i:])
+		}
+	}
+	return l.Empty()
+}
+
 // SkipLast returns a new enumerable collection that contains the elements from source with the last count elements of the source collection omitted.
 // ! this method panics when count is out of range.
 func (l linq[T]) SkipLast(count int) Linq[T] {
@@ -289,21 +460,45 @@ func (l linq[T]) SkipLast(count int) Linq[T] {
 	return l.Take(len(l.items) - count)
 }
 
-// Select projects each element of linq into a new form by incorporating the element's index.
-func Select[T, S any](items []T, delegate func(T) S) Linq[S] {
-	res := make([]S, len(items))
-	for i, elem := range items {
-		res[i] = delegate(elem)
+// TrySkipLast returns a new enumerable collection that contains the elements from source with the last count elements of the source collection omitted.
+// It returns ErrOutOfRange when count is negative or greater than the length of the sequence.
+func (l linq[T]) TrySkipLast(count int) (Linq[T], error) {
+	return l.TryTake(len(l.items) - count)
+}
+
+// Select projects each element of linq into a new form.
+func Select[T, S any](items Enumerable[T], delegate func(T) S) Linq[S] {
+	res := []S{}
+	for elem := range items.Values() {
+		res = append(res, delegate(elem))
+	}
+	return New(res)
+}
+
+// SelectIndexed projects each element of linq into a new form by incorporating the element's index.
+func SelectIndexed[T, S any](items Enumerable[T], delegate func(int, T) S) Linq[S] {
+	res := []S{}
+	for elem := range items.Values() {
+		res = append(res, delegate(len(res), elem))
+	}
+	return New(res)
+}
+
+// Index returns a sequence of pairs of each element's index and the element.
+func Index[T any](items Enumerable[T]) Linq[Pair[int, T]] {
+	res := []Pair[int, T]{}
+	for elem := range items.Values() {
+		res = append(res, NewPair(len(res), elem))
 	}
 	return New(res)
 }
 
 // SelectMany takes a slice of slices and a selector function,
 // and returns a flattened slice of elements selected by the selector function.
-func SelectMany[T any, U any](items []T, selector func(T) []U) Linq[U] {
+func SelectMany[T any, U any](items Enumerable[T], selector func(T) []U) Linq[U] {
 	var res []U
 
-	for _, t := range items {
+	for t := range items.Values() {
 		res = append(res, selector(t)...)
 	}
 
@@ -311,25 +506,19 @@ func SelectMany[T any, U any](items []T, selector func(T) []U) Linq[U] {
 }
 
 // OrderBy sorts the elements of a sequence in ascending order according to a key.
-func OrderBy[L any, O constraints.Ordered](items []L, comparer func(L) O) Linq[L] {
-	sort.SliceStable(items, func(i, j int) bool {
-		return comparer(items[i]) < comparer(items[j])
-	})
-	return New(items)
+func OrderBy[L any, O constraints.Ordered](items Enumerable[L], comparer func(L) O) OrderedLinq[L] {
+	return newOrderedLinq(collect(items), ascending(comparer))
 }
 
 // OrderByDescending sorts the elements of a sequence in descending order according to a key.
-func OrderByDescending[L any, O constraints.Ordered](items []L, comparer func(L) O) Linq[L] {
-	sort.SliceStable(items, func(i, j int) bool {
-		return comparer(items[i]) > comparer(items[j])
-	})
-	return New(items)
+func OrderByDescending[L any, O constraints.Ordered](items Enumerable[L], comparer func(L) O) OrderedLinq[L] {
+	return newOrderedLinq(collect(items), descending(comparer))
 }
 
-func GroupBy[L any, K comparable, E any](items []L, key func(L) K, element func(L) E) map[K][]E {
+func GroupBy[L any, K comparable, E any](items Enumerable[L], key func(L) K, element func(L) E) map[K][]E {
 	res := make(map[K][]E)
 
-	for _, item := range items {
+	for item := range items.Values() {
 		elem := element(item)
 		if _, ok := res[key(item)]; ok {
 			res[key(item)] = append(res[key(item)], elem)
@@ -341,19 +530,19 @@ func GroupBy[L any, K comparable, E any](items []L, key func(L) K, element func(
 }
 
 // OrderBy sorts the elements of a sequence in ascending order according to a key.
-func (l linq[T]) OrderBy(comparer func(T) int) Linq[T] {
-	sort.SliceStable(l.items, func(i, j int) bool {
-		return comparer(l.items[i]) < comparer(l.items[j])
-	})
-	return &l
+func (l linq[T]) OrderBy(comparer func(T) int) OrderedLinq[T] {
+	return newOrderedLinq(l.items, ascending(comparer))
 }
 
 // OrderByDescending sorts the elements of a sequence in descending order according to a key.
-func (l linq[T]) OrderByDescending(comparer func(T) int) Linq[T] {
-	sort.SliceStable(l.items, func(i, j int) bool {
-		return comparer(l.items[i]) > comparer(l.items[j])
-	})
-	return &l
+func (l linq[T]) OrderByDescending(comparer func(T) int) OrderedLinq[T] {
+	return newOrderedLinq(l.items, descending(comparer))
+}
+
+// OrderByFunc sorts the elements of a sequence in ascending order according to a comparison function.
+// compare returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func (l linq[T]) OrderByFunc(compare func(a, b T) int) OrderedLinq[T] {
+	return newOrderedLinq(l.items, compare)
 }
 
 // Repeat generates a sequence that contains one repeated value.
@@ -375,6 +564,28 @@ func (l linq[T]) ToSlice() []T {
 	return res
 }
 
+// Values returns an iterator over the elements of linq[T].
+func (l linq[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, elem := range l.items {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Indexed returns an iterator over the index-element pairs of linq[T].
+func (l linq[T]) Indexed() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, elem := range l.items {
+			if !yield(i, elem) {
+				return
+			}
+		}
+	}
+}
+
 // ToChannel creates a channel with values in linq[T]
 func (l linq[T]) ToChannel() <-chan T {
 	res := make(chan T, len(l.items))
@@ -386,6 +597,7 @@ func (l linq[T]) ToChannel() <-chan T {
 }
 
 // ToChannelWithBuffer creates a channel with values in linq[T] with specified buffer. (async method)
+// ! the producer goroutine blocks until every value is received, use ToChannelWithBufferContext if the consumer may stop early.
 func (l linq[T]) ToChannelWithBuffer(buffer int) <-chan T {
 	res := make(chan T, buffer)
 	go func() {
@@ -397,6 +609,24 @@ func (l linq[T]) ToChannelWithBuffer(buffer int) <-chan T {
 	return res
 }
 
+// ToChannelWithBufferContext creates a channel with values in linq[T] with specified buffer. (async method)
+// The channel is closed once every value is sent or ctx is done, whichever happens first,
+// check ctx.Err() to tell whether all the values were sent.
+func (l linq[T]) ToChannelWithBufferContext(ctx context.Context, buffer int) <-chan T {
+	res := make(chan T, buffer)
+	go func() {
+		defer close(res)
+		for _, t := range l.items {
+			select {
+			case res <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return res
+}
+
 // Creates a map[interface{}]T from an linq[T] according to a specified key selector function.
 func (l linq[T]) ToMapWithKey(keySelector func(T) interface{}) map[interface{}]T {
 	res := make(map[interface{}]T)
@@ -407,9 +637,9 @@ func (l linq[T]) ToMapWithKey(keySelector func(T) interface{}) map[interface{}]T
 }
 
 // Creates a map[TKey]TSource from an linq[TSource] according to a specified key selector function.
-func ConvertToMapWithKey[TSource any, TKey comparable](items []TSource, keySelector func(TSource) TKey) map[TKey]TSource {
+func ConvertToMapWithKey[TSource any, TKey comparable](items Enumerable[TSource], keySelector func(TSource) TKey) map[TKey]TSource {
 	res := make(map[TKey]TSource)
-	for _, item := range items {
+	for item := range items.Values() {
 		res[keySelector(item)] = item
 	}
 	return res
@@ -425,10 +655,10 @@ func (l linq[T]) ToMapWithKeyValue(keySelector func(T) interface{}, valueSelecto
 }
 
 // Creates a map[TKey,TValue] from an linq[TSource] according to specified key selector and element selector functions.
-func ConvertToMapWithKeyValue[TSource any, TKey comparable, TValue any](items []TSource, keySelector func(TSource) TKey, valueSelector func(TSource) TValue) map[TKey]TValue {
+func ConvertToMapWithKeyValue[TSource any, TKey comparable, TValue any](items Enumerable[TSource], keySelector func(TSource) TKey, valueSelector func(TSource) TValue) map[TKey]TValue {
 	res := make(map[TKey]TValue)
 
-	for _, item := range items {
+	for item := range items.Values() {
 		res[keySelector(item)] = valueSelector(item)
 	}
 	return res
@@ -506,9 +736,14 @@ func (l linq[T]) ForEach(callBack func(T)) {
 
 // ReplaceAll replaces old values by new values
 func (l linq[T]) ReplaceAll(oldValue, newValue T) Linq[T] {
+	return l.ReplaceAllBy(oldValue, newValue, DeepEqualComparer[T]())
+}
+
+// ReplaceAllBy replaces old values by new values by using the specified EqualityComparer to find the old values.
+func (l linq[T]) ReplaceAllBy(oldValue, newValue T, comparer EqualityComparer[T]) Linq[T] {
 	res := New(make([]T, 0, len(l.items)))
 	for _, elem := range l.items {
-		if equal(elem, oldValue) {
+		if comparer.Equals(elem, oldValue) {
 			res = res.Append(newValue)
 		} else {
 			res = res.Append(elem)
@@ -519,10 +754,15 @@ func (l linq[T]) ReplaceAll(oldValue, newValue T) Linq[T] {
 
 // Remove removes the first occurrence of a specific object from the linq[T].
 func (l *linq[T]) Remove(item T) bool {
+	return l.RemoveBy(item, DeepEqualComparer[T]())
+}
+
+// RemoveBy removes the first occurrence of a specific object from the linq[T] by using the specified EqualityComparer.
+func (l *linq[T]) RemoveBy(item T, comparer EqualityComparer[T]) bool {
 	res := l.Empty()
 	var isRemoved bool
 	for _, elem := range l.items {
-		if equal(elem, item) && !isRemoved {
+		if comparer.Equals(elem, item) && !isRemoved {
 			isRemoved = true
 			continue
 		}
@@ -576,6 +816,17 @@ func (l *linq[T]) RemoveRange(index, count int) error {
 	return nil
 }
 
+// Sort sorts the elements of the linq[T] in place according to a comparison function, the sort is stable.
+// compare returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func (l *linq[T]) Sort(compare func(a, b T) int) {
+	sortStable(l.items, compare)
+}
+
+// SortBy sorts the elements of the linq[T] in place in ascending order according to a key, the sort is stable.
+func (l *linq[T]) SortBy(comparer func(T) int) {
+	sortStable(l.items, ascending(comparer))
+}
+
 // Length returns the number of items in the linq[T] collection.
 func (l linq[T]) Length() int {
 	return len(l.items)
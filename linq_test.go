@@ -2,6 +2,8 @@ package linq
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"testing"
 
@@ -35,7 +37,7 @@ func Test_Int_Methods(t *testing.T) {
 		assert.Equal(5, m[50])
 	}
 	{ // ConvertToMapWithKey
-		m := ConvertToMapWithKey(si.ToSlice(), func(i int) float32 { return float32(i) * 0.1 })
+		m := ConvertToMapWithKey(si, func(i int) float32 { return float32(i) * 0.1 })
 		assert.Equal(5, m[0.5])
 	}
 	{ // ToMapWithKeyValue
@@ -43,7 +45,7 @@ func Test_Int_Methods(t *testing.T) {
 		assert.Equal(4, m[200])
 	}
 	{ // ConvertToMapWithKeyValue
-		m := ConvertToMapWithKeyValue(si.ToSlice(), func(i int) int { return i * 100 }, func(i int) int { return i * 2 })
+		m := ConvertToMapWithKeyValue(si, func(i int) int { return i * 100 }, func(i int) int { return i * 2 })
 		assert.Equal(6, m[300])
 	}
 	{ // Where
@@ -205,27 +207,27 @@ func Test_Int_Methods(t *testing.T) {
 	{ // OrderBy
 		si := New([]int{5, 8, 2, 3, 6, 9, 4, 1, 7, 0})
 		orderedSi := si.OrderBy(func(i int) int { return i })
-		assert.Equal(New([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), orderedSi)
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, orderedSi.ToSlice())
 	}
 	{ // OrderByDescending
 		si := New([]int{5, 8, 2, 3, 6, 9, 4, 1, 7, 0})
 		orderedSi := si.OrderByDescending(func(i int) int { return i })
-		assert.Equal(New([]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}), orderedSi)
+		assert.Equal([]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, orderedSi.ToSlice())
 	}
 	{ // another Order
 		si := New([]int{5, 8, 2, 3, 6, 9, 4, 1, 7, 0})
-		orderedSi := OrderBy(si.ToSlice(), func(i int) int { return i })
-		assert.Equal(New([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), orderedSi)
+		orderedSi := OrderBy(si, func(i int) int { return i })
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, orderedSi.ToSlice())
 	}
 	{ // another Order
 		si := New([]int{5, 8, 2, 3, 6, 9, 4, 1, 7, 0})
-		orderedSi := OrderBy(si.ToSlice(), func(i int) int64 { return int64(i * 20) })
-		assert.Equal(New([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), orderedSi)
+		orderedSi := OrderBy(si, func(i int) int64 { return int64(i * 20) })
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, orderedSi.ToSlice())
 	}
 	{ // another OrderByDescending
 		si := New([]int{5, 8, 2, 3, 6, 9, 4, 1, 7, 0})
-		orderedSi := OrderByDescending(si.ToSlice(), func(i int) int { return i })
-		assert.Equal(New([]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}), orderedSi)
+		orderedSi := OrderByDescending(si, func(i int) int { return i })
+		assert.Equal([]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, orderedSi.ToSlice())
 	}
 	{ // Add
 		si := New([]int{1, 2, 3})
@@ -277,7 +279,7 @@ func Test_Int_Methods(t *testing.T) {
 			1: {"1", "3", "5"},
 		}
 
-		actual := GroupBy(si.ToSlice(), keyFunc, elementFunc)
+		actual := GroupBy(si, keyFunc, elementFunc)
 
 		assert.Equal(expected, actual)
 	}
@@ -287,7 +289,7 @@ func Test_Int_Methods(t *testing.T) {
 		elementFunc := func(i int) string { return strconv.Itoa(i) }
 		expected := map[int][]string{}
 
-		actual := GroupBy(si.ToSlice(), keyFunc, elementFunc)
+		actual := GroupBy(si, keyFunc, elementFunc)
 		assert.Equal(expected, actual)
 	}
 	{ // Repeat numbers
@@ -371,13 +373,13 @@ func Test_Struct_Methods(t *testing.T) {
 	}
 	{ // Order by string
 		ss := New([]user{{name: "abc"}, {name: "apple"}, {name: "a1234567"}, {name: "a"}})
-		orderedSs := OrderBy(ss.ToSlice(), func(u user) string { return u.name })
-		assert.Equal(New([]user{{name: "a"}, {name: "a1234567"}, {name: "abc"}, {name: "apple"}}), orderedSs)
+		orderedSs := OrderBy(ss, func(u user) string { return u.name })
+		assert.Equal([]user{{name: "a"}, {name: "a1234567"}, {name: "abc"}, {name: "apple"}}, orderedSs.ToSlice())
 	}
 	{ // Order by string length
 		ss := New([]user{{name: "abc"}, {name: "apple"}, {name: "a1234567"}, {name: "a"}})
-		orderedSs := OrderBy(ss.ToSlice(), func(u user) int { return len(u.name) })
-		assert.Equal(New([]user{{name: "a"}, {name: "abc"}, {name: "apple"}, {name: "a1234567"}}), orderedSs)
+		orderedSs := OrderBy(ss, func(u user) int { return len(u.name) })
+		assert.Equal([]user{{name: "a"}, {name: "abc"}, {name: "apple"}, {name: "a1234567"}}, orderedSs.ToSlice())
 	}
 }
 
@@ -403,7 +405,7 @@ func Test_Select(t *testing.T) {
 		},
 	}
 
-	names := Select(users, func(u user) string { return u.name })
+	names := Select(Slice(users), func(u user) string { return u.name })
 	assert.Equal([]string{"Ann", "Jack", "Ian"}, names.ToSlice())
 }
 
@@ -440,7 +442,7 @@ func Test_GroupBy(t *testing.T) {
 		},
 	}
 
-	act := GroupBy(testData,
+	act := GroupBy(Slice(testData),
 		func(s str) string { return s.key },
 		func(s str) int { return s.value })
 
@@ -460,8 +462,8 @@ func TestSelectMany(t *testing.T) {
 		return []int{x * x}
 	}
 
-	result := SelectMany([][]int{slice1, slice2, slice3}, func(x []int) []int {
-		return SelectMany(x, selector).ToSlice()
+	result := SelectMany(Slice([][]int{slice1, slice2, slice3}), func(x []int) []int {
+		return SelectMany(Slice(x), selector).ToSlice()
 	})
 
 	expected := []int{1, 4, 9, 16, 25, 36, 49, 64, 81}
@@ -543,3 +545,166 @@ func Test_linq_with_all_values_sent_through_channel(t *testing.T) {
 
 	assert.ElementsMatch(t, []int{1, 2, 3}, result.ToSlice())
 }
+
+func Test_RangeOverFunc(t *testing.T) {
+	assert := assert.New(t)
+	si := New([]int{3, 1, 4, 1, 5})
+	{ // Values
+		actual := []int{}
+		for v := range si.Values() {
+			actual = append(actual, v)
+		}
+		assert.Equal([]int{3, 1, 4, 1, 5}, actual)
+	}
+	{ // Values stops when the consumer breaks
+		actual := []int{}
+		for v := range si.Values() {
+			if v == 4 {
+				break
+			}
+			actual = append(actual, v)
+		}
+		assert.Equal([]int{3, 1}, actual)
+	}
+	{ // Indexed
+		m := map[int]int{}
+		for i, v := range si.Indexed() {
+			m[i] = v
+		}
+		assert.Equal(map[int]int{0: 3, 1: 1, 2: 4, 3: 1, 4: 5}, m)
+	}
+	{ // stdlib interoperability
+		assert.Equal([]int{1, 1, 3, 4, 5}, slices.Sorted(si.Values()))
+	}
+	{ // FromSeq
+		actual := FromSeq(slices.Values([]string{"a", "b"}))
+		assert.Equal(New([]string{"a", "b"}), actual)
+	}
+	{ // FromSeq with an empty sequence
+		assert.Empty(FromSeq(slices.Values([]int(nil))).ToSlice())
+	}
+	{ // FromSeq2
+		actual := FromSeq2(slices.All([]string{"a", "b"}), func(i int, s string) string { return s + strconv.Itoa(i) })
+		assert.Equal(New([]string{"a0", "b1"}), actual)
+	}
+	{ // FromSeq2 with maps.All
+		actual := FromSeq2(maps.All(map[string]int{"a": 1, "b": 2}), func(k string, v int) string { return k + strconv.Itoa(v) })
+		assert.ElementsMatch([]string{"a1", "b2"}, actual.ToSlice())
+	}
+	{ // Query Values
+		actual := slices.Collect(si.AsQuery().Where(func(i int) bool { return i > 1 }).Values())
+		assert.Equal([]int{3, 4, 5}, actual)
+	}
+}
+
+func Test_Try_Methods(t *testing.T) {
+	assert := assert.New(t)
+	si := New([]int{0, 1, 2, 3, 4, 5})
+	empty := New([]int{})
+	isEven := func(i int) bool { return i%2 == 0 }
+	{ // TryElementAt
+		actual, err := si.TryElementAt(2)
+		assert.NoError(err)
+		assert.Equal(2, actual)
+		_, err = si.TryElementAt(6)
+		assert.ErrorIs(err, ErrOutOfRange)
+		_, err = si.TryElementAt(-1)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // TryFirst
+		actual, err := si.TryFirst(func(i int) bool { return i > 2 })
+		assert.NoError(err)
+		assert.Equal(3, actual)
+		_, err = si.TryFirst(func(i int) bool { return i > 10 })
+		assert.ErrorIs(err, ErrNoMatch)
+		_, err = empty.TryFirst(isEven)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // TryLast
+		actual, err := si.TryLast(isEven)
+		assert.NoError(err)
+		assert.Equal(4, actual)
+		_, err = si.TryLast(func(i int) bool { return i < 0 })
+		assert.ErrorIs(err, ErrNoMatch)
+		_, err = empty.TryLast(isEven)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // TrySingle
+		actual, err := si.TrySingle(func(i int) bool { return i == 3 })
+		assert.NoError(err)
+		assert.Equal(3, actual)
+		_, err = si.TrySingle(isEven)
+		assert.ErrorIs(err, ErrNotUnique)
+		_, err = si.TrySingle(func(i int) bool { return i > 10 })
+		assert.ErrorIs(err, ErrNoMatch)
+		_, err = empty.TrySingle(isEven)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // TryTake
+		actual, err := si.TryTake(2)
+		assert.NoError(err)
+		assert.Equal([]int{0, 1}, actual.ToSlice())
+		actual, err = si.TryTake(6)
+		assert.NoError(err)
+		assert.Equal(si.ToSlice(), actual.ToSlice())
+		_, err = si.TryTake(7)
+		assert.ErrorIs(err, ErrOutOfRange)
+		_, err = si.TryTake(-1)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // TryTakeLast
+		actual, err := si.TryTakeLast(2)
+		assert.NoError(err)
+		assert.Equal([]int{4, 5}, actual.ToSlice())
+		_, err = si.TryTakeLast(7)
+		assert.ErrorIs(err, ErrOutOfRange)
+		_, err = si.TryTakeLast(-1)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // TrySkip
+		actual, err := si.TrySkip(4)
+		assert.NoError(err)
+		assert.Equal([]int{4, 5}, actual.ToSlice())
+		actual, err = si.TrySkip(6)
+		assert.NoError(err)
+		assert.Empty(actual.ToSlice())
+		_, err = si.TrySkip(7)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // TrySkipLast
+		actual, err := si.TrySkipLast(4)
+		assert.NoError(err)
+		assert.Equal([]int{0, 1}, actual.ToSlice())
+		actual, err = si.TrySkipLast(0)
+		assert.NoError(err)
+		assert.Equal(si.ToSlice(), actual.ToSlice())
+		_, err = si.TrySkipLast(-1)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+}
+
+func Test_Indexed_Overloads(t *testing.T) {
+	assert := assert.New(t)
+	ss := New([]string{"a", "bb", "c", "dd", "e"})
+	{ // WhereIndexed
+		actual := ss.WhereIndexed(func(i int, s string) bool { return i%2 == 0 })
+		assert.Equal([]string{"a", "c", "e"}, actual.ToSlice())
+	}
+	{ // TakeWhileIndexed
+		actual := ss.TakeWhileIndexed(func(i int, s string) bool { return len(s) > i })
+		assert.Equal([]string{"a", "bb"}, actual.ToSlice())
+	}
+	{ // SkipWhileIndexed
+		actual := ss.SkipWhileIndexed(func(i int, s string) bool { return i < 3 || len(s) > 1 })
+		assert.Equal([]string{"e"}, actual.ToSlice())
+		assert.Empty(ss.SkipWhileIndexed(func(int, string) bool { return true }).ToSlice())
+	}
+	{ // SelectIndexed
+		actual := SelectIndexed(ss, func(i int, s string) string { return strconv.Itoa(i) + s })
+		assert.Equal([]string{"0a", "1bb", "2c", "3dd", "4e"}, actual.ToSlice())
+	}
+	{ // Index
+		actual := Index(ss.Take(2))
+		assert.Equal([]Pair[int, string]{{0, "a"}, {1, "bb"}}, actual.ToSlice())
+	}
+}
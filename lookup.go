@@ -0,0 +1,78 @@
+package linq
+
+import "slices"
+
+// Grouping is a collection of elements that share a common key.
+type Grouping[K comparable, E any] struct {
+	Linq[E]
+	Key K
+}
+
+// Lookup is a collection of keys each mapped to one or more elements.
+// The keys keep the order in which they are first seen in the source sequence.
+type Lookup[K comparable, E any] struct {
+	keys   []K
+	groups map[K][]E
+}
+
+// ToLookup creates a Lookup from a sequence according to specified key selector and element selector functions.
+func ToLookup[T any, K comparable, E any](items Enumerable[T], keySelector func(T) K, elementSelector func(T) E) Lookup[K, E] {
+	res := Lookup[K, E]{
+		keys:   []K{},
+		groups: make(map[K][]E),
+	}
+	for item := range items.Values() {
+		key := keySelector(item)
+		if _, ok := res.groups[key]; !ok {
+			res.keys = append(res.keys, key)
+		}
+		res.groups[key] = append(res.groups[key], elementSelector(item))
+	}
+	return res
+}
+
+// Contains determines whether a specified key is in the Lookup.
+func (l Lookup[K, E]) Contains(key K) bool {
+	_, ok := l.groups[key]
+	return ok
+}
+
+// Get returns the elements that have the specified key, or an empty linq[E] if the key is not in the Lookup.
+// The elements are copied, so modifying the returned linq never changes the Lookup.
+func (l Lookup[K, E]) Get(key K) Linq[E] {
+	elements, ok := l.groups[key]
+	if !ok {
+		return New([]E{})
+	}
+	return New(slices.Clone(elements))
+}
+
+// Count returns the number of keys in the Lookup.
+func (l Lookup[K, E]) Count() int {
+	return len(l.keys)
+}
+
+// Keys returns the keys of the Lookup in the order they are first seen.
+func (l Lookup[K, E]) Keys() []K {
+	return slices.Clone(l.keys)
+}
+
+// Groupings returns the groups of the Lookup in the order their keys are first seen.
+func (l Lookup[K, E]) Groupings() Linq[Grouping[K, E]] {
+	res := make([]Grouping[K, E], len(l.keys))
+	for i, key := range l.keys {
+		res[i] = Grouping[K, E]{Linq: l.Get(key), Key: key}
+	}
+	return New(res)
+}
+
+// GroupByWithResult groups the elements of a sequence according to a specified key selector function
+// and creates a result value from each group and its key. The results keep the order in which the keys are first seen.
+func GroupByWithResult[T any, K comparable, E any, R any](items Enumerable[T], keySelector func(T) K, elementSelector func(T) E, resultSelector func(K, Linq[E]) R) Linq[R] {
+	lookup := ToLookup(items, keySelector, elementSelector)
+	res := make([]R, len(lookup.keys))
+	for i, key := range lookup.keys {
+		res[i] = resultSelector(key, lookup.Get(key))
+	}
+	return New(res)
+}
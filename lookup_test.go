@@ -0,0 +1,67 @@
+package linq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Lookup(t *testing.T) {
+	assert := assert.New(t)
+	type sale struct {
+		region string
+		amount int
+	}
+	sales := []sale{{"west", 10}, {"east", 5}, {"west", 7}, {"north", 1}, {"east", 3}}
+	region := func(s sale) string { return s.region }
+	amount := func(s sale) int { return s.amount }
+	lookup := ToLookup(Slice(sales), region, amount)
+	{ // Keys keep the first seen order
+		assert.Equal([]string{"west", "east", "north"}, lookup.Keys())
+		assert.Equal(3, lookup.Count())
+	}
+	{ // Contains
+		assert.True(lookup.Contains("east"))
+		assert.False(lookup.Contains("south"))
+	}
+	{ // Get
+		assert.Equal([]int{10, 7}, lookup.Get("west").ToSlice())
+		assert.Empty(lookup.Get("south").ToSlice())
+	}
+	{ // Get returns a linq that can be queried further
+		assert.Equal(1, lookup.Get("east").Count(func(i int) bool { return i > 4 }))
+	}
+	{ // appending to the result of Get does not change the Lookup
+		west := lookup.Get("west")
+		west.Add(100)
+		assert.Equal([]int{10, 7}, lookup.Get("west").ToSlice())
+	}
+	{ // sorting the elements of the result of Get and Groupings does not change the Lookup
+		west := lookup.Get("west")
+		west.Sort(func(a, b int) int { return a - b })
+		assert.Equal([]int{7, 10}, west.ToSlice())
+		east := lookup.Groupings().ToSlice()[1]
+		east.SortBy(func(i int) int { return i })
+		assert.Equal([]int{10, 7}, lookup.Get("west").ToSlice())
+		assert.Equal([]int{5, 3}, lookup.Get("east").ToSlice())
+	}
+	{ // Groupings
+		groupings := lookup.Groupings().ToSlice()
+		assert.Len(groupings, 3)
+		assert.Equal("east", groupings[1].Key)
+		assert.Equal([]int{5, 3}, groupings[1].ToSlice())
+	}
+	{ // GroupByWithResult
+		actual := GroupByWithResult(Slice(sales), region, amount, func(key string, amounts Linq[int]) string {
+			return key + ":" + strings.Repeat("*", NewNumberLinq[int, int](amounts.ToSlice()).Sum(func(i int) int { return i }))
+		})
+		assert.Equal([]string{"west:*****************", "east:********", "north:*"}, actual.ToSlice())
+	}
+	{ // empty sequence
+		empty := ToLookup(Slice([]sale{}), region, amount)
+		assert.Equal(0, empty.Count())
+		assert.Empty(empty.Keys())
+		assert.Empty(empty.Groupings().ToSlice())
+	}
+}
@@ -1,15 +1,34 @@
 package linq
 
-import "golang.org/x/exp/constraints"
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
 
 type number interface {
 	constraints.Integer | constraints.Float
 }
 
 // NumberLinq provides following new methods:
-//  - Sum
-//  - Max
-//  - Min
+//   - Sum, CheckedSum, SumInt64, SumFloat64
+//   - Product
+//   - Average
+//   - Max
+//   - Min
+//   - MaxBy
+//   - MinBy
+//
+// and the Try variants of Average, Max, Min, MaxBy and MinBy,
+// which return ErrEmptySequence instead of panicking or returning zero for an empty sequence.
+//
+// It also provides the statistics methods:
+//   - Median
+//   - Mode
+//   - Percentile
+//   - PopulationVariance, SampleVariance
+//   - PopulationStdDev, SampleStdDev
+//   - Histogram
 type NumberLinq[T any, N number] struct {
 	linq[T]
 }
@@ -18,6 +37,8 @@ func NewNumberLinq[T any, N number](items []T) NumberLinq[T, N] {
 	return NumberLinq[T, N]{linq[T]{items: items}}
 }
 
+// Sum computes the sum of the sequence of numeric values.
+// The sum is accumulated in N, it silently wraps around on integer overflow, use CheckedSum or SumInt64 to detect it.
 func (nl NumberLinq[T, N]) Sum(selector func(T) N) N {
 	var sum N
 	for _, elem := range nl.items {
@@ -26,6 +47,95 @@ func (nl NumberLinq[T, N]) Sum(selector func(T) N) N {
 	return sum
 }
 
+// CheckedSum computes the sum of the sequence of numeric values like C# checked arithmetic.
+// It returns ErrOverflow when the sum overflows N, for floating-point types that is when the sum becomes infinite.
+func (nl NumberLinq[T, N]) CheckedSum(selector func(T) N) (N, error) {
+	var sum N
+	for _, elem := range nl.items {
+		next, ok := checkedAdd(sum, selector(elem))
+		if !ok {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// SumInt64 computes the sum of the sequence of numeric values widened to int64, the fractional part of floating-point values is truncated.
+// It returns ErrOverflow when a value or the sum does not fit in an int64.
+func (nl NumberLinq[T, N]) SumInt64(selector func(T) N) (int64, error) {
+	var sum int64
+	for _, elem := range nl.items {
+		num := selector(elem)
+		if isFloat[N]() && (float64(num) >= math.MaxInt64 || float64(num) < math.MinInt64 || math.IsNaN(float64(num))) {
+			return 0, ErrOverflow
+		}
+		widened := int64(num)
+		if num > 0 && widened < 0 {
+			// an unsigned value above math.MaxInt64
+			return 0, ErrOverflow
+		}
+		next, ok := checkedAdd(sum, widened)
+		if !ok {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// SumFloat64 computes the sum of the sequence of numeric values widened to float64.
+// It uses Neumaier's compensated summation, so the result does not lose precision when many values are added.
+func (nl NumberLinq[T, N]) SumFloat64(selector func(T) N) float64 {
+	return neumaierSum(nl.float64s(selector))
+}
+
+func isFloat[N number]() bool {
+	return N(1)/N(2) != 0
+}
+
+// checkedAdd returns a + b and whether the addition did not overflow.
+func checkedAdd[N number](a, b N) (N, bool) {
+	sum := a + b
+	if isFloat[N]() {
+		return sum, !math.IsInf(float64(sum), 0) || math.IsInf(float64(a), 0) || math.IsInf(float64(b), 0)
+	}
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return sum, false
+	}
+	return sum, true
+}
+
+// Product computes the product of the sequence of numeric values, the product of an empty sequence is 1.
+func (nl NumberLinq[T, N]) Product(selector func(T) N) N {
+	product := N(1)
+	for _, elem := range nl.items {
+		product *= selector(elem)
+	}
+	return product
+}
+
+// Average computes the average of a sequence of numeric values.
+// ! this method panics when the sequence is empty.
+func (nl NumberLinq[T, N]) Average(selector func(T) N) float64 {
+	avg, err := nl.TryAverage(selector)
+	if err != nil {
+		panic("linq: Average() empty set")
+	}
+	return avg
+}
+
+// TryAverage computes the average of a sequence of numeric values.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) TryAverage(selector func(T) N) (float64, error) {
+	if len(nl.items) == 0 {
+		return 0, ErrEmptySequence
+	}
+	return neumaierSum(nl.float64s(selector)) / float64(len(nl.items)), nil
+}
+
+// Max returns the maximum value in a sequence of values.
+// It returns zero for an empty sequence, use TryMax to tell an empty sequence apart.
 func (nl NumberLinq[T, N]) Max(selector func(T) N) N {
 	var max N
 	for i, elem := range nl.items {
@@ -37,6 +147,17 @@ func (nl NumberLinq[T, N]) Max(selector func(T) N) N {
 	return max
 }
 
+// TryMax returns the maximum value in a sequence of values.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) TryMax(selector func(T) N) (N, error) {
+	if len(nl.items) == 0 {
+		return 0, ErrEmptySequence
+	}
+	return nl.Max(selector), nil
+}
+
+// Min returns the minimum value in a sequence of values.
+// It returns zero for an empty sequence, use TryMin to tell an empty sequence apart.
 func (nl NumberLinq[T, N]) Min(selector func(T) N) N {
 	var min N
 	for i, elem := range nl.items {
@@ -47,3 +168,60 @@ func (nl NumberLinq[T, N]) Min(selector func(T) N) N {
 	}
 	return min
 }
+
+// TryMin returns the minimum value in a sequence of values.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) TryMin(selector func(T) N) (N, error) {
+	if len(nl.items) == 0 {
+		return 0, ErrEmptySequence
+	}
+	return nl.Min(selector), nil
+}
+
+// MaxBy returns the first element with the maximum key in a sequence.
+// ! this method panics when the sequence is empty.
+func (nl NumberLinq[T, N]) MaxBy(keySelector func(T) N) T {
+	elem, err := nl.TryMaxBy(keySelector)
+	if err != nil {
+		panic("linq: MaxBy() empty set")
+	}
+	return elem
+}
+
+// TryMaxBy returns the first element with the maximum key in a sequence.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) TryMaxBy(keySelector func(T) N) (T, error) {
+	return nl.extremumBy(keySelector, func(a, b N) bool { return a > b })
+}
+
+// MinBy returns the first element with the minimum key in a sequence.
+// ! this method panics when the sequence is empty.
+func (nl NumberLinq[T, N]) MinBy(keySelector func(T) N) T {
+	elem, err := nl.TryMinBy(keySelector)
+	if err != nil {
+		panic("linq: MinBy() empty set")
+	}
+	return elem
+}
+
+// TryMinBy returns the first element with the minimum key in a sequence.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) TryMinBy(keySelector func(T) N) (T, error) {
+	return nl.extremumBy(keySelector, func(a, b N) bool { return a < b })
+}
+
+// extremumBy returns the first element whose key is better than the keys of all the elements before it.
+func (nl NumberLinq[T, N]) extremumBy(keySelector func(T) N, better func(a, b N) bool) (T, error) {
+	var res T
+	if len(nl.items) == 0 {
+		return res, ErrEmptySequence
+	}
+	var best N
+	for i, elem := range nl.items {
+		key := keySelector(elem)
+		if i == 0 || better(key, best) {
+			res, best = elem, key
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,255 @@
+package linq
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NumberLinq_Aggregates(t *testing.T) {
+	assert := assert.New(t)
+	type product struct {
+		name  string
+		price float64
+		stock int
+	}
+	products := NewNumberLinq[product, float64]([]product{
+		{"pen", 1.5, 100},
+		{"book", 12, 20},
+		{"lamp", 30, 3},
+		{"desk", 30, 1},
+	})
+	stocks := NewNumberLinq[product, int](products.ToSlice())
+	empty := NewNumberLinq[product, int]([]product{})
+	price := func(p product) float64 { return p.price }
+	stock := func(p product) int { return p.stock }
+	{ // Average
+		assert.Equal(18.375, products.Average(price))
+		assert.Equal(31.0, stocks.Average(stock))
+		assert.Panics(func() { empty.Average(stock) })
+	}
+	{ // TryAverage
+		avg, err := stocks.TryAverage(stock)
+		assert.NoError(err)
+		assert.Equal(31.0, avg)
+		_, err = empty.TryAverage(stock)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // Product
+		assert.Equal(6000, stocks.Product(stock))
+		assert.Equal(1, empty.Product(stock))
+	}
+	{ // MaxBy returns the first element with the maximum key
+		assert.Equal("lamp", products.MaxBy(price).name)
+		assert.Panics(func() { empty.MaxBy(stock) })
+	}
+	{ // MinBy
+		assert.Equal("desk", stocks.MinBy(stock).name)
+		assert.Panics(func() { empty.MinBy(stock) })
+	}
+	{ // TryMaxBy and TryMinBy
+		p, err := stocks.TryMaxBy(stock)
+		assert.NoError(err)
+		assert.Equal("pen", p.name)
+		p, err = products.TryMinBy(price)
+		assert.NoError(err)
+		assert.Equal("pen", p.name)
+		_, err = empty.TryMaxBy(stock)
+		assert.ErrorIs(err, ErrEmptySequence)
+		_, err = empty.TryMinBy(stock)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // Max and Min return zero for an empty sequence, TryMax and TryMin tell it apart
+		assert.Equal(0, empty.Max(stock))
+		assert.Equal(0, empty.Min(stock))
+		_, err := empty.TryMax(stock)
+		assert.ErrorIs(err, ErrEmptySequence)
+		_, err = empty.TryMin(stock)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // TryMax and TryMin
+		negatives := NewNumberLinq[int, int]([]int{-3, -1, -7})
+		identity := func(i int) int { return i }
+		max, err := negatives.TryMax(identity)
+		assert.NoError(err)
+		assert.Equal(-1, max)
+		min, err := negatives.TryMin(identity)
+		assert.NoError(err)
+		assert.Equal(-7, min)
+	}
+}
+
+func Test_NumberLinq_Statistics(t *testing.T) {
+	assert := assert.New(t)
+	identity := func(i int) int { return i }
+	fIdentity := func(f float64) float64 { return f }
+	data := NewNumberLinq[int, int]([]int{2, 4, 4, 4, 5, 5, 7, 9})
+	empty := NewNumberLinq[int, int]([]int{})
+	{ // Median
+		median, err := data.Median(identity)
+		assert.NoError(err)
+		assert.Equal(4.5, median)
+		median, err = NewNumberLinq[int, int]([]int{9, 1, 5}).Median(identity)
+		assert.NoError(err)
+		assert.Equal(5.0, median)
+		_, err = empty.Median(identity)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // Mode
+		mode, err := data.Mode(identity)
+		assert.NoError(err)
+		assert.Equal([]int{4}, mode)
+		mode, err = NewNumberLinq[int, int]([]int{3, 1, 3, 1, 2}).Mode(identity)
+		assert.NoError(err)
+		assert.Equal([]int{1, 3}, mode)
+		_, err = empty.Mode(identity)
+		assert.ErrorIs(err, ErrEmptySequence)
+	}
+	{ // Percentile, expected values computed with numpy.percentile([1, 2, 3, 4], 40, method=...)
+		nums := NewNumberLinq[int, int]([]int{4, 1, 3, 2})
+		for interpolation, expected := range map[Interpolation]float64{
+			InterpolationLinear:   2.2,
+			InterpolationLower:    2,
+			InterpolationHigher:   3,
+			InterpolationNearest:  2,
+			InterpolationMidpoint: 2.5,
+		} {
+			actual, err := nums.Percentile(identity, 40, interpolation)
+			assert.NoError(err)
+			assert.InDelta(expected, actual, 1e-12, "interpolation %d", interpolation)
+		}
+		p0, _ := nums.Percentile(identity, 0, InterpolationLinear)
+		p100, _ := nums.Percentile(identity, 100, InterpolationLinear)
+		assert.Equal(1.0, p0)
+		assert.Equal(4.0, p100)
+		_, err := nums.Percentile(identity, 101, InterpolationLinear)
+		assert.ErrorIs(err, ErrOutOfRange)
+		_, err = nums.Percentile(identity, -1, InterpolationLinear)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // variance and standard deviation
+		variance, err := data.PopulationVariance(identity)
+		assert.NoError(err)
+		assert.Equal(4.0, variance)
+		stdDev, err := data.PopulationStdDev(identity)
+		assert.NoError(err)
+		assert.Equal(2.0, stdDev)
+		variance, err = data.SampleVariance(identity)
+		assert.NoError(err)
+		assert.InDelta(32.0/7, variance, 1e-12)
+		stdDev, err = data.SampleStdDev(identity)
+		assert.NoError(err)
+		assert.InDelta(2.138089935299395, stdDev, 1e-12)
+	}
+	{ // variance is numerically stable with a large offset
+		shifted := NewNumberLinq[float64, float64]([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16})
+		variance, err := shifted.SampleVariance(fIdentity)
+		assert.NoError(err)
+		assert.Equal(30.0, variance)
+	}
+	{ // variance of too few elements
+		_, err := empty.PopulationVariance(identity)
+		assert.ErrorIs(err, ErrEmptySequence)
+		_, err = NewNumberLinq[int, int]([]int{1}).SampleVariance(identity)
+		assert.ErrorIs(err, ErrInsufficientData)
+		_, err = NewNumberLinq[int, int]([]int{1}).SampleStdDev(identity)
+		assert.ErrorIs(err, ErrInsufficientData)
+	}
+	{ // Average uses compensated summation
+		values := []float64{1, 1e100, 1, -1e100}
+		avg, err := NewNumberLinq[float64, float64](values).TryAverage(fIdentity)
+		assert.NoError(err)
+		assert.Equal(0.5, avg)
+	}
+	{ // Histogram
+		bins, err := NewNumberLinq[int, int]([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}).Histogram(identity, 5)
+		assert.NoError(err)
+		assert.Equal([]HistogramBin{
+			{Lower: 0, Upper: 2, Count: 2},
+			{Lower: 2, Upper: 4, Count: 2},
+			{Lower: 4, Upper: 6, Count: 2},
+			{Lower: 6, Upper: 8, Count: 2},
+			{Lower: 8, Upper: 10, Count: 2},
+		}, bins)
+	}
+	{ // Histogram of equal values
+		bins, err := NewNumberLinq[int, int]([]int{3, 3, 3}).Histogram(identity, 2)
+		assert.NoError(err)
+		assert.Equal([]HistogramBin{{Lower: 3, Upper: 3, Count: 3}, {Lower: 3, Upper: 3, Count: 0}}, bins)
+	}
+	{ // Histogram errors
+		_, err := empty.Histogram(identity, 3)
+		assert.ErrorIs(err, ErrEmptySequence)
+		_, err = data.Histogram(identity, 0)
+		assert.ErrorIs(err, ErrOutOfRange)
+	}
+	{ // Histogram of non-finite values
+		for _, v := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+			_, err := NewNumberLinq[float64, float64]([]float64{1, v, 2}).Histogram(fIdentity, 3)
+			assert.ErrorIs(err, ErrNotFinite)
+		}
+		_, err := NewNumberLinq[float64, float64]([]float64{-math.MaxFloat64, math.MaxFloat64}).Histogram(fIdentity, 3)
+		assert.ErrorIs(err, ErrOverflow)
+	}
+}
+
+func Test_NumberLinq_SumModes(t *testing.T) {
+	assert := assert.New(t)
+	{ // Sum wraps around, CheckedSum reports the overflow
+		i8 := NewNumberLinq[int8, int8]([]int8{100, 27, 1})
+		identity := func(i int8) int8 { return i }
+		assert.Equal(int8(-128), i8.Sum(identity))
+		_, err := i8.CheckedSum(identity)
+		assert.ErrorIs(err, ErrOverflow)
+		sum, err := NewNumberLinq[int8, int8]([]int8{100, 27, -50}).CheckedSum(identity)
+		assert.NoError(err)
+		assert.Equal(int8(77), sum)
+	}
+	{ // CheckedSum negative overflow
+		_, err := NewNumberLinq[int32, int32]([]int32{math.MinInt32, -1}).CheckedSum(func(i int32) int32 { return i })
+		assert.ErrorIs(err, ErrOverflow)
+	}
+	{ // CheckedSum unsigned overflow
+		_, err := NewNumberLinq[uint8, uint8]([]uint8{200, 56}).CheckedSum(func(i uint8) uint8 { return i })
+		assert.ErrorIs(err, ErrOverflow)
+	}
+	{ // CheckedSum floating-point overflow
+		identity := func(f float32) float32 { return f }
+		_, err := NewNumberLinq[float32, float32]([]float32{math.MaxFloat32, math.MaxFloat32}).CheckedSum(identity)
+		assert.ErrorIs(err, ErrOverflow)
+		sum, err := NewNumberLinq[float32, float32]([]float32{1.5, 2.25}).CheckedSum(identity)
+		assert.NoError(err)
+		assert.Equal(float32(3.75), sum)
+	}
+	{ // SumInt64 widens the values
+		sum, err := NewNumberLinq[int8, int8]([]int8{100, 27, 1}).SumInt64(func(i int8) int8 { return i })
+		assert.NoError(err)
+		assert.Equal(int64(128), sum)
+		sum, err = NewNumberLinq[float64, float64]([]float64{1.9, -2.9}).SumInt64(func(f float64) float64 { return f })
+		assert.NoError(err)
+		assert.Equal(int64(-1), sum)
+	}
+	{ // SumInt64 overflow
+		_, err := NewNumberLinq[int64, int64]([]int64{math.MaxInt64, 1}).SumInt64(func(i int64) int64 { return i })
+		assert.ErrorIs(err, ErrOverflow)
+		_, err = NewNumberLinq[uint64, uint64]([]uint64{math.MaxUint64}).SumInt64(func(i uint64) uint64 { return i })
+		assert.ErrorIs(err, ErrOverflow)
+		_, err = NewNumberLinq[float64, float64]([]float64{1e19}).SumInt64(func(f float64) float64 { return f })
+		assert.ErrorIs(err, ErrOverflow)
+	}
+	{ // SumFloat64 keeps the precision of many float32 values
+		values := make([]float32, 1_000_000)
+		for i := range values {
+			values[i] = 0.1
+		}
+		nl := NewNumberLinq[float32, float32](values)
+		identity := func(f float32) float32 { return f }
+		assert.InDelta(100_000, nl.SumFloat64(identity), 0.01)
+		assert.Greater(math.Abs(float64(nl.Sum(identity))-100_000), 1.0)
+	}
+	{ // SumFloat64 compensates cancellation
+		identity := func(f float64) float64 { return f }
+		assert.Equal(2.0, NewNumberLinq[float64, float64]([]float64{1, 1e100, 1, -1e100}).SumFloat64(identity))
+	}
+}
@@ -0,0 +1,201 @@
+package linq
+
+import (
+	"math"
+	"slices"
+)
+
+// Interpolation selects how Percentile estimates a value that falls between two data points.
+// The methods follow the definitions of numpy.percentile.
+type Interpolation int
+
+const (
+	// InterpolationLinear interpolates linearly between the two nearest data points.
+	InterpolationLinear Interpolation = iota
+	// InterpolationLower takes the lower of the two nearest data points.
+	InterpolationLower
+	// InterpolationHigher takes the higher of the two nearest data points.
+	InterpolationHigher
+	// InterpolationNearest takes the nearest data point, ties are resolved to the data point with the even index.
+	InterpolationNearest
+	// InterpolationMidpoint takes the average of the two nearest data points.
+	InterpolationMidpoint
+)
+
+// HistogramBin is a bucket of a histogram, it counts the values in the half-open interval [Lower, Upper).
+// The last bin of a histogram also counts the values equal to its Upper bound.
+type HistogramBin struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// neumaierSum returns the sum of values using Neumaier's improved Kahan summation,
+// which keeps the rounding error independent of the number of values.
+func neumaierSum(values []float64) float64 {
+	var sum, compensation float64
+	for _, v := range values {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			compensation += (sum - t) + v
+		} else {
+			compensation += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + compensation
+}
+
+func (nl NumberLinq[T, N]) float64s(selector func(T) N) []float64 {
+	res := make([]float64, len(nl.items))
+	for i, elem := range nl.items {
+		res[i] = float64(selector(elem))
+	}
+	return res
+}
+
+// Median computes the median of a sequence of numeric values, the average of the two middle values is used for an even length.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) Median(selector func(T) N) (float64, error) {
+	return nl.Percentile(selector, 50, InterpolationLinear)
+}
+
+// Mode returns the most frequent values of a sequence of numeric values in ascending order.
+// Every value that occurs the maximum number of times is returned.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) Mode(selector func(T) N) ([]N, error) {
+	if len(nl.items) == 0 {
+		return nil, ErrEmptySequence
+	}
+	counts := make(map[N]int)
+	var max int
+	for _, elem := range nl.items {
+		num := selector(elem)
+		counts[num]++
+		if counts[num] > max {
+			max = counts[num]
+		}
+	}
+	res := []N{}
+	for num, count := range counts {
+		if count == max {
+			res = append(res, num)
+		}
+	}
+	slices.Sort(res)
+	return res, nil
+}
+
+// Percentile computes the p-th percentile of a sequence of numeric values, p must be in the range [0, 100].
+// It returns ErrEmptySequence when the sequence is empty and ErrOutOfRange when p is out of range.
+func (nl NumberLinq[T, N]) Percentile(selector func(T) N, p float64, interpolation Interpolation) (float64, error) {
+	if len(nl.items) == 0 {
+		return 0, ErrEmptySequence
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ErrOutOfRange
+	}
+	sorted := nl.float64s(selector)
+	slices.Sort(sorted)
+
+	h := float64(len(sorted)-1) * p / 100
+	lower, upper := sorted[int(math.Floor(h))], sorted[int(math.Ceil(h))]
+	switch interpolation {
+	case InterpolationLower:
+		return lower, nil
+	case InterpolationHigher:
+		return upper, nil
+	case InterpolationNearest:
+		return sorted[int(math.RoundToEven(h))], nil
+	case InterpolationMidpoint:
+		return (lower + upper) / 2, nil
+	default:
+		return lower + (h-math.Floor(h))*(upper-lower), nil
+	}
+}
+
+// welford returns the number of values, their mean and the sum of squared differences from the mean,
+// computed in a single pass with Welford's algorithm.
+func (nl NumberLinq[T, N]) welford(selector func(T) N) (count int, mean, m2 float64) {
+	for _, elem := range nl.items {
+		x := float64(selector(elem))
+		count++
+		delta := x - mean
+		mean += delta / float64(count)
+		m2 += delta * (x - mean)
+	}
+	return count, mean, m2
+}
+
+// PopulationVariance computes the variance of a sequence of numeric values that is the whole population.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) PopulationVariance(selector func(T) N) (float64, error) {
+	count, _, m2 := nl.welford(selector)
+	if count == 0 {
+		return 0, ErrEmptySequence
+	}
+	return m2 / float64(count), nil
+}
+
+// SampleVariance computes the unbiased variance of a sequence of numeric values that is a sample of the population.
+// It returns ErrInsufficientData when the sequence has less than two elements.
+func (nl NumberLinq[T, N]) SampleVariance(selector func(T) N) (float64, error) {
+	count, _, m2 := nl.welford(selector)
+	if count < 2 {
+		return 0, ErrInsufficientData
+	}
+	return m2 / float64(count-1), nil
+}
+
+// PopulationStdDev computes the standard deviation of a sequence of numeric values that is the whole population.
+// It returns ErrEmptySequence when the sequence is empty.
+func (nl NumberLinq[T, N]) PopulationStdDev(selector func(T) N) (float64, error) {
+	variance, err := nl.PopulationVariance(selector)
+	return math.Sqrt(variance), err
+}
+
+// SampleStdDev computes the standard deviation of a sequence of numeric values that is a sample of the population.
+// It returns ErrInsufficientData when the sequence has less than two elements.
+func (nl NumberLinq[T, N]) SampleStdDev(selector func(T) N) (float64, error) {
+	variance, err := nl.SampleVariance(selector)
+	return math.Sqrt(variance), err
+}
+
+// Histogram buckets a sequence of numeric values into the specified number of bins of equal width between the minimum and the maximum value.
+// When all the values are equal every bin has a zero width and all the values are counted in the first bin.
+// It returns ErrEmptySequence when the sequence is empty, ErrOutOfRange when bins is not positive,
+// ErrNotFinite when a value is infinite or NaN, and ErrOverflow when the range of the values overflows float64.
+func (nl NumberLinq[T, N]) Histogram(selector func(T) N, bins int) ([]HistogramBin, error) {
+	if len(nl.items) == 0 {
+		return nil, ErrEmptySequence
+	}
+	if bins <= 0 {
+		return nil, ErrOutOfRange
+	}
+	values := nl.float64s(selector)
+	for _, v := range values {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, ErrNotFinite
+		}
+	}
+	lowest, highest := slices.Min(values), slices.Max(values)
+	if math.IsInf(highest-lowest, 0) {
+		return nil, ErrOverflow
+	}
+	width := (highest - lowest) / float64(bins)
+
+	res := make([]HistogramBin, bins)
+	for i := range res {
+		res[i].Lower = lowest + float64(i)*width
+		res[i].Upper = lowest + float64(i+1)*width
+	}
+	res[bins-1].Upper = highest
+	for _, v := range values {
+		i := 0
+		if width > 0 {
+			i = min(int((v-lowest)/width), bins-1)
+		}
+		res[i].Count++
+	}
+	return res, nil
+}
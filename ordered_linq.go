@@ -0,0 +1,88 @@
+package linq
+
+import (
+	"cmp"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// OrderedLinq is a Linq[T] returned by the ordering methods.
+// Ordering never reorders the source sequence, the sorted elements are a copy.
+// Subsequent orderings are added with ThenBy, ThenByDescending and ThenByFunc,
+// they only apply to the elements that are equal according to the previous orderings.
+type OrderedLinq[T any] interface {
+	Linq[T]
+	// ThenBy performs a subsequent ordering of the elements in a sequence in ascending order according to a key.
+	ThenBy(comparer func(T) int) OrderedLinq[T]
+	// ThenByDescending performs a subsequent ordering of the elements in a sequence in descending order according to a key.
+	ThenByDescending(comparer func(T) int) OrderedLinq[T]
+	// ThenByFunc performs a subsequent ordering of the elements in a sequence in ascending order according to a comparison function.
+	ThenByFunc(compare func(a, b T) int) OrderedLinq[T]
+}
+
+type orderedLinq[T any] struct {
+	*linq[T]
+	compare func(a, b T) int
+}
+
+// newOrderedLinq stable sorts a copy of items according to compare, items itself is left untouched.
+func newOrderedLinq[T any](items []T, compare func(a, b T) int) OrderedLinq[T] {
+	res := make([]T, len(items))
+	copy(res, items)
+	sortStable(res, compare)
+	return &orderedLinq[T]{
+		linq:    &linq[T]{items: res},
+		compare: compare,
+	}
+}
+
+func sortStable[T any](items []T, compare func(a, b T) int) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return compare(items[i], items[j]) < 0
+	})
+}
+
+func ascending[T any, K constraints.Ordered](keySelector func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(keySelector(a), keySelector(b))
+	}
+}
+
+func descending[T any, K constraints.Ordered](keySelector func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(keySelector(b), keySelector(a))
+	}
+}
+
+// ThenBy performs a subsequent ordering of the elements in a sequence in ascending order according to a key.
+func (o orderedLinq[T]) ThenBy(comparer func(T) int) OrderedLinq[T] {
+	return o.ThenByFunc(ascending(comparer))
+}
+
+// ThenByDescending performs a subsequent ordering of the elements in a sequence in descending order according to a key.
+func (o orderedLinq[T]) ThenByDescending(comparer func(T) int) OrderedLinq[T] {
+	return o.ThenByFunc(descending(comparer))
+}
+
+// ThenByFunc performs a subsequent ordering of the elements in a sequence in ascending order according to a comparison function.
+// compare returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func (o orderedLinq[T]) ThenByFunc(compare func(a, b T) int) OrderedLinq[T] {
+	previous := o.compare
+	return newOrderedLinq(o.items, func(a, b T) int {
+		if res := previous(a, b); res != 0 {
+			return res
+		}
+		return compare(a, b)
+	})
+}
+
+// ThenBy performs a subsequent ordering of the elements in a sequence in ascending order according to a key.
+func ThenBy[T any, K constraints.Ordered](items OrderedLinq[T], keySelector func(T) K) OrderedLinq[T] {
+	return items.ThenByFunc(ascending(keySelector))
+}
+
+// ThenByDescending performs a subsequent ordering of the elements in a sequence in descending order according to a key.
+func ThenByDescending[T any, K constraints.Ordered](items OrderedLinq[T], keySelector func(T) K) OrderedLinq[T] {
+	return items.ThenByFunc(descending(keySelector))
+}
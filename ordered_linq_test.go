@@ -0,0 +1,119 @@
+package linq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OrderedLinq(t *testing.T) {
+	assert := assert.New(t)
+	type person struct {
+		last  string
+		first string
+		age   int
+	}
+	people := []person{
+		{"Smith", "John", 40},
+		{"Doe", "Jane", 31},
+		{"Smith", "Anna", 25},
+		{"Doe", "Jane", 22},
+		{"Brown", "Zoe", 50},
+		{"Smith", "Anna", 61},
+	}
+	lastName := func(p person) string { return p.last }
+	firstName := func(p person) string { return p.first }
+	age := func(p person) int { return p.age }
+	{ // ThenBy with ordered keys of different types
+		actual := ThenBy(ThenBy(OrderBy(New(people), lastName), firstName), age)
+		assert.Equal([]person{
+			{"Brown", "Zoe", 50},
+			{"Doe", "Jane", 22},
+			{"Doe", "Jane", 31},
+			{"Smith", "Anna", 25},
+			{"Smith", "Anna", 61},
+			{"Smith", "John", 40},
+		}, actual.ToSlice())
+	}
+	{ // ThenByDescending
+		actual := ThenByDescending(OrderByDescending(New(people), lastName), age)
+		assert.Equal([]person{
+			{"Smith", "Anna", 61},
+			{"Smith", "John", 40},
+			{"Smith", "Anna", 25},
+			{"Doe", "Jane", 31},
+			{"Doe", "Jane", 22},
+			{"Brown", "Zoe", 50},
+		}, actual.ToSlice())
+	}
+	{ // ThenBy and ThenByDescending methods
+		actual := New(people).Clone().
+			OrderBy(func(p person) int { return len(p.last) }).
+			ThenByDescending(age).
+			ThenBy(func(p person) int { return len(p.first) })
+		assert.Equal([]person{
+			{"Doe", "Jane", 31},
+			{"Doe", "Jane", 22},
+			{"Smith", "Anna", 61},
+			{"Brown", "Zoe", 50},
+			{"Smith", "John", 40},
+			{"Smith", "Anna", 25},
+		}, actual.ToSlice())
+	}
+	{ // OrderByFunc and ThenByFunc
+		actual := New(people).Clone().
+			OrderByFunc(func(a, b person) int { return strings.Compare(a.first, b.first) }).
+			ThenByFunc(func(a, b person) int { return b.age - a.age })
+		assert.Equal([]int{61, 25, 31, 22, 40, 50}, Select(actual, age).ToSlice())
+	}
+	{ // the ordering is stable
+		actual := New(people).Clone().OrderByFunc(func(a, b person) int { return strings.Compare(a.last, b.last) })
+		assert.Equal([]int{50, 31, 22, 40, 25, 61}, Select(actual, age).ToSlice())
+	}
+	{ // OrderedLinq is a Linq
+		actual := OrderBy(New(people), age).Where(func(p person) bool { return p.last == "Smith" }).Take(2)
+		assert.Equal([]int{25, 40}, Select(actual, age).ToSlice())
+	}
+}
+
+// Ordering sorts a copy and leaves the source, and every linq sharing it, untouched.
+func Test_Ordering_DoesNotMutateSource(t *testing.T) {
+	assert := assert.New(t)
+	source := []int{5, 8, 2, 3}
+	si := New(source)
+	identity := func(i int) int { return i }
+	{ // OrderBy method
+		assert.Equal([]int{2, 3, 5, 8}, si.OrderBy(identity).ToSlice())
+		assert.Equal([]int{8, 5, 3, 2}, si.OrderByDescending(identity).ToSlice())
+		assert.Equal([]int{2, 3, 5, 8}, si.OrderByFunc(func(a, b int) int { return a - b }).ToSlice())
+	}
+	{ // package OrderBy
+		assert.Equal([]int{2, 3, 5, 8}, OrderBy(Slice(source), identity).ToSlice())
+		assert.Equal([]int{8, 5, 3, 2}, OrderByDescending(Slice(source), identity).ToSlice())
+	}
+	{ // ThenBy
+		ordered := OrderBy(Slice(source), func(i int) int { return i % 2 })
+		assert.Equal([]int{8, 2, 5, 3}, ordered.ToSlice())
+		assert.Equal([]int{2, 8, 3, 5}, ordered.ThenBy(identity).ToSlice())
+		assert.Equal([]int{8, 2, 5, 3}, ordered.ToSlice())
+	}
+	assert.Equal([]int{5, 8, 2, 3}, source)
+	assert.Equal([]int{5, 8, 2, 3}, si.ToSlice())
+}
+
+func Test_Sort_InPlace(t *testing.T) {
+	assert := assert.New(t)
+	{ // Sort
+		source := []int{5, 8, 2, 3}
+		si := New(source)
+		si.Sort(func(a, b int) int { return b - a })
+		assert.Equal([]int{8, 5, 3, 2}, si.ToSlice())
+		assert.Equal([]int{8, 5, 3, 2}, source)
+	}
+	{ // SortBy
+		si := New([]string{"ccc", "a", "bb", "d"})
+		si.SortBy(func(s string) int { return len(s) })
+		assert.Equal([]string{"a", "d", "bb", "ccc"}, si.ToSlice())
+	}
+}
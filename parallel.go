@@ -0,0 +1,193 @@
+package linq
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelOptions configures how a ParallelQuery partitions its elements and how many goroutines process them.
+type ParallelOptions struct {
+	// Degree is the maximum number of goroutines used by an operator, runtime.GOMAXPROCS(0) when not positive.
+	Degree int
+	// ChunkSize is the number of contiguous elements handed to a goroutine at a time.
+	// When not positive, the elements are split into about four chunks per goroutine.
+	ChunkSize int
+}
+
+func (opts ParallelOptions) degree() int {
+	if opts.Degree > 0 {
+		return opts.Degree
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (opts ParallelOptions) chunkSize(length int) int {
+	if opts.ChunkSize > 0 {
+		return opts.ChunkSize
+	}
+	chunks := opts.degree() * 4
+	return max(1, (length+chunks-1)/chunks)
+}
+
+// ParallelQuery is a sequence whose operators run on several goroutines, the parallel counterpart of linq[T].
+// The elements are split into contiguous chunks that are handed to at most Degree goroutines,
+// the results of the chunks are then merged into a new ParallelQuery.
+// By default the merged results are in no particular order, call AsOrdered to preserve the order of the source.
+// The delegates passed to the operators must be safe for concurrent use.
+// A panic in a delegate is recovered and raised again on the calling goroutine as a *DelegatePanicError,
+// whose Index is the index of the element in the ParallelQuery.
+type ParallelQuery[T any] struct {
+	items   []T
+	opts    ParallelOptions
+	ordered bool
+}
+
+// AsParallel creates a ParallelQuery from the elements of items.
+func AsParallel[T any](items Enumerable[T], opts ParallelOptions) ParallelQuery[T] {
+	return ParallelQuery[T]{items: collect(items), opts: opts}
+}
+
+// AsOrdered returns a ParallelQuery whose operators preserve the order of the source.
+func (q ParallelQuery[T]) AsOrdered() ParallelQuery[T] {
+	q.ordered = true
+	return q
+}
+
+// AsUnordered returns a ParallelQuery whose operators merge the results in no particular order.
+func (q ParallelQuery[T]) AsUnordered() ParallelQuery[T] {
+	q.ordered = false
+	return q
+}
+
+// Where filters a sequence of values based on a predicate.
+func (q ParallelQuery[T]) Where(predicate func(T) bool) ParallelQuery[T] {
+	return mapChunks(q, func(chunk []T, at *int) []T {
+		res := []T{}
+		for i, elem := range chunk {
+			*at = i
+			if predicate(elem) {
+				res = append(res, elem)
+			}
+		}
+		return res
+	})
+}
+
+// ForAll invokes the specified action on each element of the sequence, the order of the calls is not specified.
+func (q ParallelQuery[T]) ForAll(action func(T)) {
+	q.forEachChunk(func(_ int, chunk []T, at *int) {
+		for i, elem := range chunk {
+			*at = i
+			action(elem)
+		}
+	})
+}
+
+// ToSlice creates a slice from the elements of the sequence.
+func (q ParallelQuery[T]) ToSlice() []T {
+	return append([]T{}, q.items...)
+}
+
+// ToLinq creates a linq[T] from the elements of the sequence.
+func (q ParallelQuery[T]) ToLinq() Linq[T] {
+	return New(q.ToSlice())
+}
+
+// ParallelSelect projects each element of a ParallelQuery into a new form.
+func ParallelSelect[T, S any](q ParallelQuery[T], selector func(T) S) ParallelQuery[S] {
+	return mapChunks(q, func(chunk []T, at *int) []S {
+		res := make([]S, len(chunk))
+		for i, elem := range chunk {
+			*at = i
+			res[i] = selector(elem)
+		}
+		return res
+	})
+}
+
+// ParallelAggregate applies an accumulator function over a ParallelQuery.
+// Every chunk is accumulated separately, starting from a fresh value returned by seed,
+// the partial results are then merged in the order of the chunks by combine, again starting from a value returned by seed.
+// seed must therefore return the identity of combine, such as 0 for a sum.
+func ParallelAggregate[T, A any](q ParallelQuery[T], seed func() A, accumulate func(A, T) A, combine func(A, A) A) A {
+	partials := make([]A, q.chunks())
+	q.forEachChunk(func(index int, chunk []T, at *int) {
+		acc := seed()
+		for i, elem := range chunk {
+			*at = i
+			acc = accumulate(acc, elem)
+		}
+		partials[index] = acc
+	})
+	res := seed()
+	for _, partial := range partials {
+		res = combine(res, partial)
+	}
+	return res
+}
+
+// mapChunks transforms every chunk of q in parallel and merges the transformed chunks into a new ParallelQuery.
+func mapChunks[T, S any](q ParallelQuery[T], transform func(chunk []T, at *int) []S) ParallelQuery[S] {
+	res := ParallelQuery[S]{opts: q.opts, ordered: q.ordered}
+	if q.ordered {
+		results := make([][]S, q.chunks())
+		q.forEachChunk(func(index int, chunk []T, at *int) {
+			results[index] = transform(chunk, at)
+		})
+		res.items = []S{}
+		for _, result := range results {
+			res.items = append(res.items, result...)
+		}
+		return res
+	}
+	m := sync.Mutex{}
+	res.items = []S{}
+	q.forEachChunk(func(_ int, chunk []T, at *int) {
+		result := transform(chunk, at)
+		m.Lock()
+		res.items = append(res.items, result...)
+		m.Unlock()
+	})
+	return res
+}
+
+func (q ParallelQuery[T]) chunks() int {
+	size := q.opts.chunkSize(len(q.items))
+	return (len(q.items) + size - 1) / size
+}
+
+// forEachChunk calls process for every chunk of q on at most Degree goroutines and waits for all of them to return.
+// process stores in at the position in the chunk of the element it is working on, so that a panic can be attributed to that element.
+// ! this method panics with a *DelegatePanicError on the calling goroutine when process panics, no more chunks are started after the panic.
+func (q ParallelQuery[T]) forEachChunk(process func(index int, chunk []T, at *int)) {
+	size := q.opts.chunkSize(len(q.items))
+	chunks := q.chunks()
+	var next atomic.Int64
+	var panicErr atomic.Pointer[DelegatePanicError]
+	wg := sync.WaitGroup{}
+	for range min(q.opts.degree(), chunks) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := int(next.Add(1) - 1); index < chunks && panicErr.Load() == nil; index = int(next.Add(1) - 1) {
+				start := index * size
+				chunk := q.items[start:min(start+size, len(q.items))]
+				at := 0
+				func() {
+					defer func() {
+						if recovered := recover(); recovered != nil {
+							panicErr.CompareAndSwap(nil, &DelegatePanicError{Index: start + at, Value: chunk[at], Recovered: recovered, Stack: debug.Stack()})
+						}
+					}()
+					process(index, chunk, &at)
+				}()
+			}
+		}()
+	}
+	wg.Wait()
+	if err := panicErr.Load(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,171 @@
+package linq
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParallelQuery_Methods(t *testing.T) {
+	assert := assert.New(t)
+	source := make([]int, 1000)
+	for i := range source {
+		source[i] = i
+	}
+	even := func(i int) bool { return i%2 == 0 }
+	expectedEven := New(source).Where(even).ToSlice()
+	pq := AsParallel(Slice(source), ParallelOptions{Degree: 4, ChunkSize: 16})
+	{ // Where
+		assert.ElementsMatch(expectedEven, pq.Where(even).ToSlice())
+	}
+	{ // AsOrdered
+		assert.Equal(expectedEven, pq.AsOrdered().Where(even).ToSlice())
+		actual := ParallelSelect(pq.AsOrdered().Where(even), func(i int) string { return fmt.Sprint(i) }).ToSlice()
+		assert.Equal(Select(New(expectedEven), func(i int) string { return fmt.Sprint(i) }).ToSlice(), actual)
+	}
+	{ // ParallelSelect
+		actual := ParallelSelect(pq, func(i int) int { return i * 2 }).ToSlice()
+		assert.ElementsMatch(Select(New(source), func(i int) int { return i * 2 }).ToSlice(), actual)
+	}
+	{ // ParallelAggregate
+		sum := ParallelAggregate(pq, func() int { return 0 }, func(acc, i int) int { return acc + i }, func(a, b int) int { return a + b })
+		assert.Equal(999*1000/2, sum)
+		joined := ParallelAggregate(AsParallel(Slice([]string{"a", "b", "c", "d", "e"}), ParallelOptions{ChunkSize: 2}),
+			func() string { return "" },
+			func(acc, s string) string { return acc + s },
+			func(a, b string) string { return a + b })
+		assert.Equal("abcde", joined)
+	}
+	{ // ForAll
+		var sum atomic.Int64
+		pq.ForAll(func(i int) { sum.Add(int64(i)) })
+		assert.Equal(int64(999*1000/2), sum.Load())
+	}
+	{ // ToLinq
+		assert.Equal(New([]int{0, 1, 2}), AsParallel(New([]int{0, 1, 2}), ParallelOptions{}).AsOrdered().ToLinq())
+	}
+	{ // empty sequence
+		empty := AsParallel(Slice([]int{}), ParallelOptions{})
+		assert.Empty(empty.Where(even).ToSlice())
+		assert.Equal(0, ParallelAggregate(empty, func() int { return 0 }, func(acc, i int) int { return acc + i }, func(a, b int) int { return a + b }))
+	}
+}
+
+// The first Degree calls wait for each other, so the test only passes when the workers actually overlap.
+func Test_ParallelQuery_Degree(t *testing.T) {
+	assert := assert.New(t)
+	const degree = 3
+	source := make([]int, 200)
+	var entered, running, peak atomic.Int32
+	release := make(chan struct{})
+	AsParallel(Slice(source), ParallelOptions{Degree: degree, ChunkSize: 1}).ForAll(func(int) {
+		current := running.Add(1)
+		for {
+			highest := peak.Load()
+			if current <= highest || peak.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+		if entered.Add(1) == degree {
+			close(release)
+		}
+		select {
+		case <-release:
+		case <-time.After(time.Second):
+		}
+		running.Add(-1)
+	})
+	assert.Greater(peak.Load(), int32(1))
+	assert.LessOrEqual(peak.Load(), int32(degree))
+}
+
+func Test_ParallelQuery_PanicRecovery(t *testing.T) {
+	assert := assert.New(t)
+	pq := AsParallel(New([]int{1, 2, 3, 4, 5, 6, 7, 8}), ParallelOptions{Degree: 2, ChunkSize: 3})
+	explode := func(i int) bool {
+		if i == 5 {
+			panic("boom")
+		}
+		return true
+	}
+	recoverPanic := func(f func()) (err *DelegatePanicError) {
+		defer func() {
+			err, _ = recover().(*DelegatePanicError)
+		}()
+		f()
+		return nil
+	}
+	for name, f := range map[string]func(){
+		"Where":   func() { pq.Where(explode) },
+		"Ordered": func() { pq.AsOrdered().Where(explode) },
+		"ForAll":  func() { pq.ForAll(func(i int) { explode(i) }) },
+		"Select":  func() { ParallelSelect(pq, explode) },
+		"Aggregate": func() {
+			ParallelAggregate(pq, func() int { return 0 }, func(acc, i int) int { explode(i); return acc + i }, func(a, b int) int { return a + b })
+		},
+	} {
+		panicErr := recoverPanic(f)
+		if assert.NotNil(panicErr, name) {
+			assert.Equal(4, panicErr.Index, name)
+			assert.Equal(5, panicErr.Value, name)
+			assert.Equal("boom", panicErr.Recovered, name)
+			assert.NotEmpty(panicErr.Stack, name)
+		}
+	}
+}
+
+func benchmarkWork(i int) bool {
+	x := float64(i)
+	for j := 0; j < 50; j++ {
+		x = math.Sqrt(x + float64(j))
+	}
+	return x > 7
+}
+
+func Benchmark_Parallel_Where(b *testing.B) {
+	source := make([]int, 1_000_000)
+	for i := range source {
+		source[i] = i
+	}
+	b.Run("Linq", func(b *testing.B) {
+		l := New(source)
+		for i := 0; i < b.N; i++ {
+			l.Where(benchmarkWork)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		pq := AsParallel(Slice(source), ParallelOptions{})
+		for i := 0; i < b.N; i++ {
+			pq.Where(benchmarkWork)
+		}
+	})
+	b.Run("ParallelOrdered", func(b *testing.B) {
+		pq := AsParallel(Slice(source), ParallelOptions{}).AsOrdered()
+		for i := 0; i < b.N; i++ {
+			pq.Where(benchmarkWork)
+		}
+	})
+}
+
+func Benchmark_Parallel_Aggregate(b *testing.B) {
+	source := make([]int, 1_000_000)
+	for i := range source {
+		source[i] = i
+	}
+	b.Run("Linq", func(b *testing.B) {
+		l := New(source)
+		for i := 0; i < b.N; i++ {
+			Aggregate(l, 0, func(acc, i int) int { return acc + i })
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		pq := AsParallel(Slice(source), ParallelOptions{})
+		for i := 0; i < b.N; i++ {
+			ParallelAggregate(pq, func() int { return 0 }, func(acc, i int) int { return acc + i }, func(a, b int) int { return a + b })
+		}
+	})
+}
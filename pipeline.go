@@ -0,0 +1,84 @@
+package linq
+
+// Stage is a step of a pipeline that turns a Linq[T] into a Linq[S].
+// Go methods cannot have type parameters, so steps that change the element type are built with the generic functions
+// Map, FlatMap and Filter, composed with Then and run with Pipe, Pipe2, Pipe3 or Pipe4.
+// Any function of the same shape can be used as a Stage, including method expressions such as Linq[int].Distinct.
+type Stage[T, S any] func(Linq[T]) Linq[S]
+
+// Apply runs the stage on a sequence.
+func (s Stage[T, S]) Apply(items Enumerable[T]) Linq[S] {
+	return s(pipeInput(items))
+}
+
+// Where returns a stage that filters the output of s based on a predicate.
+func (s Stage[T, S]) Where(predicate func(S) bool) Stage[T, S] {
+	return func(items Linq[T]) Linq[S] {
+		return s(items).Where(predicate)
+	}
+}
+
+// Map returns a stage that projects each element of a sequence into a new form.
+func Map[T, S any](selector func(T) S) Stage[T, S] {
+	return func(items Linq[T]) Linq[S] {
+		res := make([]S, 0, items.Length())
+		items.ForEach(func(t T) {
+			res = append(res, selector(t))
+		})
+		return New(res)
+	}
+}
+
+// FlatMap returns a stage that projects each element of a sequence to a slice and flattens the slices into one sequence.
+func FlatMap[T, S any](selector func(T) []S) Stage[T, S] {
+	return func(items Linq[T]) Linq[S] {
+		res := []S{}
+		items.ForEach(func(t T) {
+			res = append(res, selector(t)...)
+		})
+		return New(res)
+	}
+}
+
+// Filter returns a stage that filters a sequence based on a predicate.
+func Filter[T any](predicate func(T) bool) Stage[T, T] {
+	return func(items Linq[T]) Linq[T] {
+		return items.Where(predicate)
+	}
+}
+
+// Then composes two stages into a stage that runs first and then second.
+func Then[A, B, C any](first Stage[A, B], second Stage[B, C]) Stage[A, C] {
+	return func(items Linq[A]) Linq[C] {
+		return second(first(items))
+	}
+}
+
+// Pipe runs a stage on a sequence.
+func Pipe[A, B any](items Enumerable[A], s1 Stage[A, B]) Linq[B] {
+	return s1(pipeInput(items))
+}
+
+// Pipe2 runs two stages on a sequence, one after another.
+func Pipe2[A, B, C any](items Enumerable[A], s1 Stage[A, B], s2 Stage[B, C]) Linq[C] {
+	return s2(s1(pipeInput(items)))
+}
+
+// Pipe3 runs three stages on a sequence, one after another.
+func Pipe3[A, B, C, D any](items Enumerable[A], s1 Stage[A, B], s2 Stage[B, C], s3 Stage[C, D]) Linq[D] {
+	return s3(s2(s1(pipeInput(items))))
+}
+
+// Pipe4 runs four stages on a sequence, one after another.
+func Pipe4[A, B, C, D, E any](items Enumerable[A], s1 Stage[A, B], s2 Stage[B, C], s3 Stage[C, D], s4 Stage[D, E]) Linq[E] {
+	return s4(s3(s2(s1(pipeInput(items)))))
+}
+
+// pipeInput returns items as a Linq[T], a Linq[T] is passed to the first stage as is
+// and the other sources are copied, so that a stage never changes the slice of a Slice adapter.
+func pipeInput[T any](items Enumerable[T]) Linq[T] {
+	if l, ok := items.(Linq[T]); ok {
+		return l
+	}
+	return FromSeq(items.Values())
+}
@@ -0,0 +1,66 @@
+package linq
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pipeline(t *testing.T) {
+	assert := assert.New(t)
+	type user struct {
+		name string
+		age  int
+		tags string
+	}
+	users := New([]user{
+		{"Ann", 12, "a,b"},
+		{"Jack", 31, "b"},
+		{"Ian", 45, "c,a"},
+		{"Bob", 17, ""},
+	})
+	isAdult := func(u user) bool { return u.age >= 18 }
+	name := func(u user) string { return u.name }
+	{ // Pipe
+		actual := Pipe(users, Map(name).Where(func(s string) bool { return len(s) == 3 }))
+		assert.Equal([]string{"Ann", "Ian", "Bob"}, actual.ToSlice())
+	}
+	{ // Pipe2 keeps fluency across a type change
+		actual := Pipe2(users, Filter(isAdult), Map(name))
+		assert.Equal([]string{"Jack", "Ian"}, actual.ToSlice())
+	}
+	{ // Pipe3 with a method expression stage
+		actual := Pipe3(users,
+			FlatMap(func(u user) []string { return strings.Split(u.tags, ",") }),
+			Filter(func(s string) bool { return s != "" }),
+			Linq[string].Distinct,
+		)
+		assert.Equal([]string{"a", "b", "c"}, actual.ToSlice())
+	}
+	{ // Pipe4
+		actual := Pipe4(users,
+			Filter(isAdult),
+			Map(func(u user) int { return u.age }),
+			Map(func(i int) int { return i * 2 }),
+			Map(strconv.Itoa),
+		)
+		assert.Equal([]string{"62", "90"}, actual.ToSlice())
+	}
+	{ // Then composes reusable stages
+		adultNames := Then(Filter(isAdult), Map(name))
+		lengths := Then(adultNames, Map(func(s string) int { return len(s) }))
+		assert.Equal([]int{4, 3}, lengths.Apply(users).ToSlice())
+		assert.Equal([]string{"Jack", "Ian"}, adultNames.Apply(users).ToSlice())
+	}
+	{ // the source is not modified
+		Pipe(users, Filter(isAdult))
+		assert.Equal(4, users.Length())
+	}
+	{ // any Enumerable can be piped
+		assert.Equal([]string{"Jack", "Ian"}, Pipe2(Slice(users.ToSlice()), Filter(isAdult), Map(name)).ToSlice())
+		assert.Equal([]int{2, 4}, Map(func(i int) int { return i * 2 }).Apply(Seq(slices.Values([]int{1, 2}))).ToSlice())
+	}
+}
@@ -0,0 +1,312 @@
+package linq
+
+import "iter"
+
+// Iterator is a pull iterator.
+// Each call returns the next element of the sequence and true, or the zero value of T and false once the sequence is exhausted.
+type Iterator[T any] func() (T, bool)
+
+// Query is a lazily evaluated sequence, the deferred-execution counterpart of linq[T].
+// Operators on a Query only describe the computation; nothing is enumerated until a terminal method such as ToSlice is called,
+// and the enumeration stops as soon as the consumer stops pulling elements.
+// A Query can be enumerated any number of times, every enumeration starts from the beginning of the source.
+type Query[T any] struct {
+	iterate func() Iterator[T]
+}
+
+// Query constructor
+// The slice is not copied, changes made to it before the query is enumerated are visible to the query.
+func NewQuery[T any](slice []T) Query[T] {
+	return Query[T]{
+		iterate: func() Iterator[T] {
+			i := 0
+			return func() (T, bool) {
+				if i >= len(slice) {
+					var defaultValue T
+					return defaultValue, false
+				}
+				i++
+				return slice[i-1], true
+			}
+		},
+	}
+}
+
+// NewQueryFromIterator creates a Query from an iterator factory.
+// The factory is called once per enumeration and must return a fresh iterator every time.
+func NewQueryFromIterator[T any](factory func() Iterator[T]) Query[T] {
+	return Query[T]{iterate: factory}
+}
+
+// AsQuery returns a Query that lazily enumerates the elements of linq[T].
+func (l linq[T]) AsQuery() Query[T] {
+	return NewQuery(l.items)
+}
+
+// Iterator returns a new pull iterator positioned at the beginning of the sequence.
+func (q Query[T]) Iterator() Iterator[T] {
+	if q.iterate == nil {
+		return emptyIterator[T]
+	}
+	return q.iterate()
+}
+
+func emptyIterator[T any]() (T, bool) {
+	var defaultValue T
+	return defaultValue, false
+}
+
+// Where filters a sequence of values based on a predicate.
+func (q Query[T]) Where(predicate func(T) bool) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		return func() (T, bool) {
+			for elem, ok := next(); ok; elem, ok = next() {
+				if predicate(elem) {
+					return elem, true
+				}
+			}
+			var defaultValue T
+			return defaultValue, false
+		}
+	})
+}
+
+// Skip bypasses a specified number of elements in a sequence and then returns the remaining elements.
+// A count less than or equal to zero skips nothing, a count greater than the length of the sequence yields an empty sequence.
+func (q Query[T]) Skip(count int) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		skipped := 0
+		return func() (T, bool) {
+			for ; skipped < count; skipped++ {
+				if _, ok := next(); !ok {
+					var defaultValue T
+					return defaultValue, false
+				}
+			}
+			return next()
+		}
+	})
+}
+
+// SkipWhile bypasses elements in a sequence as long as a specified condition is true and then returns the remaining elements.
+func (q Query[T]) SkipWhile(predicate func(T) bool) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		skipping := true
+		return func() (T, bool) {
+			if !skipping {
+				return next()
+			}
+			skipping = false
+			for elem, ok := next(); ok; elem, ok = next() {
+				if !predicate(elem) {
+					return elem, true
+				}
+			}
+			var defaultValue T
+			return defaultValue, false
+		}
+	})
+}
+
+// Take returns a specified number of contiguous elements from the start of a sequence.
+// The source is not pulled any further once count elements have been yielded.
+func (q Query[T]) Take(count int) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		taken := 0
+		return func() (T, bool) {
+			if taken >= count {
+				var defaultValue T
+				return defaultValue, false
+			}
+			taken++
+			return next()
+		}
+	})
+}
+
+// TakeWhile returns elements from a sequence as long as a specified condition is true.
+func (q Query[T]) TakeWhile(predicate func(T) bool) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		done := false
+		return func() (T, bool) {
+			var defaultValue T
+			if done {
+				return defaultValue, false
+			}
+			elem, ok := next()
+			if !ok || !predicate(elem) {
+				done = true
+				return defaultValue, false
+			}
+			return elem, true
+		}
+	})
+}
+
+// Reverse inverts the order of the elements in a sequence.
+// The source is buffered when the first element is pulled.
+func (q Query[T]) Reverse() Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		var buffer []T
+		buffered := false
+		return func() (T, bool) {
+			if !buffered {
+				buffer = q.ToSlice()
+				buffered = true
+			}
+			if len(buffer) == 0 {
+				var defaultValue T
+				return defaultValue, false
+			}
+			elem := buffer[len(buffer)-1]
+			buffer = buffer[:len(buffer)-1]
+			return elem, true
+		}
+	})
+}
+
+// Distinct returns distinct elements from a sequence by using the default equality comparer to compare values.
+func (q Query[T]) Distinct() Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		seen := []T{}
+		return func() (T, bool) {
+			for elem, ok := next(); ok; elem, ok = next() {
+				if !(linq[T]{items: seen}).Contains(elem) {
+					seen = append(seen, elem)
+					return elem, true
+				}
+			}
+			var defaultValue T
+			return defaultValue, false
+		}
+	})
+}
+
+// Append appends values to the end of the sequence.
+func (q Query[T]) Append(t ...T) Query[T] {
+	return q.Concat(NewQuery(t))
+}
+
+// Prepend adds values to the beginning of the sequence.
+func (q Query[T]) Prepend(t ...T) Query[T] {
+	return NewQuery(t).Concat(q)
+}
+
+// Concat concatenates two sequences.
+func (q Query[T]) Concat(other Query[T]) Query[T] {
+	return NewQueryFromIterator(func() Iterator[T] {
+		next := q.Iterator()
+		first := true
+		return func() (T, bool) {
+			elem, ok := next()
+			if !ok && first {
+				first = false
+				next = other.Iterator()
+				return next()
+			}
+			return elem, ok
+		}
+	})
+}
+
+// QuerySelect projects each element of a Query into a new form.
+// The selector is only called for the elements that are actually pulled.
+func QuerySelect[T, S any](q Query[T], selector func(T) S) Query[S] {
+	return NewQueryFromIterator(func() Iterator[S] {
+		next := q.Iterator()
+		return func() (S, bool) {
+			elem, ok := next()
+			if !ok {
+				var defaultValue S
+				return defaultValue, false
+			}
+			return selector(elem), true
+		}
+	})
+}
+
+/* ---------------------------- terminal methods ---------------------------- */
+
+// ForEach performs the specified action on each element of the sequence.
+func (q Query[T]) ForEach(callBack func(T)) {
+	next := q.Iterator()
+	for elem, ok := next(); ok; elem, ok = next() {
+		callBack(elem)
+	}
+}
+
+// ToSlice enumerates the sequence and creates a slice from its elements.
+func (q Query[T]) ToSlice() []T {
+	res := []T{}
+	q.ForEach(func(t T) {
+		res = append(res, t)
+	})
+	return res
+}
+
+// Values returns an iterator over the elements of the sequence.
+func (q Query[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		next := q.Iterator()
+		for elem, ok := next(); ok; elem, ok = next() {
+			if !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// ToLinq enumerates the sequence and creates a linq[T] from its elements.
+func (q Query[T]) ToLinq() Linq[T] {
+	return New(q.ToSlice())
+}
+
+// Any determines whether any element of a sequence satisfies a condition.
+func (q Query[T]) Any(predicate func(T) bool) bool {
+	next := q.Iterator()
+	for elem, ok := next(); ok; elem, ok = next() {
+		if predicate(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// All determines whether all elements of a sequence satisfy a condition.
+func (q Query[T]) All(predicate func(T) bool) bool {
+	return !q.Any(func(t T) bool { return !predicate(t) })
+}
+
+// Contains determines whether a sequence contains a specified element.
+func (q Query[T]) Contains(target T) bool {
+	return q.Any(func(t T) bool { return equal(t, target) })
+}
+
+// Count returns a number that represents how many elements in the specified sequence satisfy a condition.
+func (q Query[T]) Count(predicate func(T) bool) int {
+	var count int
+	q.ForEach(func(t T) {
+		if predicate(t) {
+			count++
+		}
+	})
+	return count
+}
+
+// FirstOrDefault returns the first element of a sequence that satisfies a condition, or a default value if no such element is found.
+func (q Query[T]) FirstOrDefault(predicate func(T) bool) T {
+	next := q.Iterator()
+	for elem, ok := next(); ok; elem, ok = next() {
+		if predicate(elem) {
+			return elem
+		}
+	}
+	var defaultValue T
+	return defaultValue
+}
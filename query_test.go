@@ -0,0 +1,96 @@
+package linq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Query_Methods(t *testing.T) {
+	assert := assert.New(t)
+	q := New([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}).AsQuery()
+	{ // ToSlice
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, q.ToSlice())
+	}
+	{ // Where
+		actual := q.Where(func(i int) bool { return i%2 == 0 }).ToSlice()
+		assert.Equal([]int{0, 2, 4, 6, 8}, actual)
+	}
+	{ // Skip
+		assert.Equal([]int{7, 8, 9}, q.Skip(7).ToSlice())
+		assert.Equal([]int{}, q.Skip(20).ToSlice())
+	}
+	{ // Take
+		assert.Equal([]int{0, 1, 2}, q.Take(3).ToSlice())
+		assert.Equal([]int{}, q.Take(-1).ToSlice())
+		assert.Equal(10, len(q.Take(20).ToSlice()))
+	}
+	{ // TakeWhile
+		actual := q.TakeWhile(func(i int) bool { return i < 3 }).ToSlice()
+		assert.Equal([]int{0, 1, 2}, actual)
+	}
+	{ // SkipWhile
+		actual := q.SkipWhile(func(i int) bool { return i < 8 }).ToSlice()
+		assert.Equal([]int{8, 9}, actual)
+	}
+	{ // Reverse
+		assert.Equal([]int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, q.Reverse().ToSlice())
+	}
+	{ // Distinct
+		actual := NewQuery([]int{1, 2, 3, 1, 5, 5, 2, 3, 8}).Distinct().ToSlice()
+		assert.Equal([]int{1, 2, 3, 5, 8}, actual)
+	}
+	{ // Append and Prepend
+		actual := q.Take(2).Append(20, 30).Prepend(-1).ToSlice()
+		assert.Equal([]int{-1, 0, 1, 20, 30}, actual)
+	}
+	{ // QuerySelect
+		actual := QuerySelect(q.Take(3), func(i int) string { return string(rune('a' + i)) }).ToSlice()
+		assert.Equal([]string{"a", "b", "c"}, actual)
+	}
+	{ // terminal methods
+		assert.True(q.Any(func(i int) bool { return i > 8 }))
+		assert.False(q.All(func(i int) bool { return i > 8 }))
+		assert.True(q.Contains(3))
+		assert.Equal(5, q.Count(func(i int) bool { return i%2 == 1 }))
+		assert.Equal(4, q.FirstOrDefault(func(i int) bool { return i > 3 }))
+	}
+	{ // ToLinq
+		assert.Equal(New([]int{8, 9}), q.Skip(8).ToLinq())
+	}
+	{ // zero value
+		var empty Query[int]
+		assert.Equal([]int{}, empty.Where(NoPredict[int]()).ToSlice())
+	}
+}
+
+// Operators must not pull more elements from the source than the consumer asks for.
+func Test_Query_IsDeferred(t *testing.T) {
+	assert := assert.New(t)
+	visited := 0
+	q := NewQuery([]int{1, 2, 3, 4, 5, 6, 7, 8}).Where(func(i int) bool {
+		visited++
+		return i%2 == 0
+	})
+	assert.Equal(0, visited)
+
+	assert.Equal([]int{2, 4}, q.Take(2).ToSlice())
+	assert.Equal(4, visited)
+
+	visited = 0
+	doubled := QuerySelect(q, func(i int) int { return i * 2 })
+	assert.Equal(12, doubled.FirstOrDefault(func(i int) bool { return i > 10 }))
+	assert.Equal(6, visited)
+}
+
+// Every enumeration of a query starts from the beginning of the source.
+func Test_Query_IsReEnumerable(t *testing.T) {
+	assert := assert.New(t)
+	source := []int{1, 2, 3}
+	q := NewQuery(source).Skip(1)
+	assert.Equal([]int{2, 3}, q.ToSlice())
+	assert.Equal([]int{2, 3}, q.ToSlice())
+
+	source[2] = 30
+	assert.Equal([]int{2, 30}, q.ToSlice())
+}
@@ -0,0 +1,105 @@
+package linq
+
+// Union produces the set union of two sequences by using the default equality comparer, in the order the elements are first seen.
+func (l linq[T]) Union(other Linq[T]) Linq[T] {
+	return union(l.items, other.ToSlice(), DeepEqualComparer[T]())
+}
+
+// Intersect produces the set intersection of two sequences by using the default equality comparer, in the order the elements appear in the first sequence.
+func (l linq[T]) Intersect(other Linq[T]) Linq[T] {
+	return intersect(l.items, other.ToSlice(), DeepEqualComparer[T]())
+}
+
+// Except produces the set difference of two sequences by using the default equality comparer, in the order the elements appear in the first sequence.
+func (l linq[T]) Except(other Linq[T]) Linq[T] {
+	return except(l.items, other.ToSlice(), DeepEqualComparer[T]())
+}
+
+func union[T any](first, second []T, comparer EqualityComparer[T]) Linq[T] {
+	res := []T{}
+	set := newHashSet(comparer)
+	for _, items := range [][]T{first, second} {
+		for _, elem := range items {
+			if set.Add(elem) {
+				res = append(res, elem)
+			}
+		}
+	}
+	return New(res)
+}
+
+func intersect[T any](first, second []T, comparer EqualityComparer[T]) Linq[T] {
+	res := []T{}
+	set := newHashSet(comparer)
+	for _, elem := range second {
+		set.Add(elem)
+	}
+	returned := newHashSet(comparer)
+	for _, elem := range first {
+		if set.Contains(elem) && returned.Add(elem) {
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
+func except[T any](first, second []T, comparer EqualityComparer[T]) Linq[T] {
+	res := []T{}
+	set := newHashSet(comparer)
+	for _, elem := range second {
+		set.Add(elem)
+	}
+	for _, elem := range first {
+		if set.Add(elem) {
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
+// UnionBy produces the set union of two sequences according to a specified key selector function.
+// The first element seen for every key is kept.
+func UnionBy[T any, K comparable](first, second Enumerable[T], keySelector func(T) K) Linq[T] {
+	res := []T{}
+	seen := make(map[K]struct{})
+	for _, items := range []Enumerable[T]{first, second} {
+		for elem := range items.Values() {
+			key := keySelector(elem)
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				res = append(res, elem)
+			}
+		}
+	}
+	return New(res)
+}
+
+// IntersectBy produces the set intersection of two sequences according to a specified key selector function.
+// It returns the elements of first whose key is contained in keys, the first element seen for every key is kept.
+func IntersectBy[T any, K comparable](first Enumerable[T], keys Enumerable[K], keySelector func(T) K) Linq[T] {
+	res := []T{}
+	set := toSet(collect(keys))
+	for elem := range first.Values() {
+		key := keySelector(elem)
+		if _, ok := set[key]; ok {
+			delete(set, key)
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
+
+// ExceptBy produces the set difference of two sequences according to a specified key selector function.
+// It returns the elements of first whose key is not contained in keys, the first element seen for every key is kept.
+func ExceptBy[T any, K comparable](first Enumerable[T], keys Enumerable[K], keySelector func(T) K) Linq[T] {
+	res := []T{}
+	set := toSet(collect(keys))
+	for elem := range first.Values() {
+		key := keySelector(elem)
+		if _, ok := set[key]; !ok {
+			set[key] = struct{}{}
+			res = append(res, elem)
+		}
+	}
+	return New(res)
+}
@@ -0,0 +1,57 @@
+package linq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Set_Methods(t *testing.T) {
+	assert := assert.New(t)
+	first := New([]int{5, 3, 9, 7, 5, 9, 3, 7})
+	second := New([]int{8, 3, 6, 4, 4, 9, 1, 0})
+	{ // Union
+		assert.Equal([]int{5, 3, 9, 7, 8, 6, 4, 1, 0}, first.Union(second).ToSlice())
+	}
+	{ // Intersect
+		assert.Equal([]int{3, 9}, first.Intersect(second).ToSlice())
+	}
+	{ // Except
+		assert.Equal([]int{5, 7}, first.Except(second).ToSlice())
+	}
+	{ // with an empty sequence
+		empty := New([]int{})
+		assert.Equal([]int{5, 3, 9, 7}, first.Union(empty).ToSlice())
+		assert.Empty(first.Intersect(empty).ToSlice())
+		assert.Equal([]int{5, 3, 9, 7}, first.Except(empty).ToSlice())
+	}
+	{ // ComparableLinq gives the same results
+		cl := NewComparableLinq(first.ToSlice())
+		assert.Equal(first.Union(second), cl.Union(second))
+		assert.Equal(first.Intersect(second), cl.Intersect(second))
+		assert.Equal(first.Except(second), cl.Except(second))
+	}
+}
+
+func Test_Set_By_Functions(t *testing.T) {
+	assert := assert.New(t)
+	type permission struct {
+		user  string
+		scope string
+	}
+	granted := New([]permission{{"ann", "read"}, {"jack", "read"}, {"ann", "write"}, {"ian", "admin"}})
+	requested := New([]permission{{"jack", "write"}, {"bob", "read"}})
+	byUser := func(p permission) string { return p.user }
+	{ // UnionBy
+		actual := UnionBy(granted, requested, byUser)
+		assert.Equal([]permission{{"ann", "read"}, {"jack", "read"}, {"ian", "admin"}, {"bob", "read"}}, actual.ToSlice())
+	}
+	{ // IntersectBy
+		actual := IntersectBy(granted, New([]string{"ian", "ann", "nobody"}), byUser)
+		assert.Equal([]permission{{"ann", "read"}, {"ian", "admin"}}, actual.ToSlice())
+	}
+	{ // ExceptBy
+		actual := ExceptBy(granted, New([]string{"jack"}), byUser)
+		assert.Equal([]permission{{"ann", "read"}, {"ian", "admin"}}, actual.ToSlice())
+	}
+}
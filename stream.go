@@ -0,0 +1,244 @@
+package linq
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"time"
+)
+
+// Stream is a possibly unbounded sequence of values received from a channel, the streaming counterpart of linq[T].
+// Every operator starts a goroutine that forwards the values to a new unbuffered channel as soon as they are received,
+// and closes it once the source channel is closed or the context of the stream is done.
+// Cancel the context to release the goroutines of a stream whose consumer stops reading.
+// ! a Stream can be consumed only once: the operators applied to the same Stream compete for its values
+// instead of each receiving all of them, use Tee to branch a stream.
+type Stream[T any] struct {
+	ctx   context.Context
+	c     <-chan T
+	clock Clock
+}
+
+// FromChannel creates a Stream that receives the values of a channel until it is closed or ctx is done.
+func FromChannel[T any](ctx context.Context, c <-chan T) Stream[T] {
+	return Stream[T]{ctx: ctx, c: c, clock: realClock{}}
+}
+
+// WithClock returns a Stream that uses the specified clock for Throttle and StreamBatch, and passes it on to the streams derived from it.
+func (s Stream[T]) WithClock(clock Clock) Stream[T] {
+	s.clock = clock
+	return s
+}
+
+// Chan returns the channel of the stream.
+func (s Stream[T]) Chan() <-chan T {
+	return s.c
+}
+
+// Values returns an iterator over the values of the stream, it stops once the channel is closed or the context is done.
+func (s Stream[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := receive(s.ctx, s.c)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Where filters the values of a stream based on a predicate.
+func (s Stream[T]) Where(predicate func(T) bool) Stream[T] {
+	return forward(s, func(out chan<- T) {
+		for v := range s.Values() {
+			if predicate(v) && !send(s.ctx, out, v) {
+				return
+			}
+		}
+	})
+}
+
+// Throttle forwards the values of a stream at most once per interval, the values that arrive faster are delayed, not dropped.
+func (s Stream[T]) Throttle(interval time.Duration) Stream[T] {
+	return forward(s, func(out chan<- T) {
+		var next time.Time
+		for v := range s.Values() {
+			if wait := next.Sub(s.clock.Now()); wait > 0 {
+				select {
+				case <-s.clock.After(wait):
+				case <-s.ctx.Done():
+					return
+				}
+			}
+			if !send(s.ctx, out, v) {
+				return
+			}
+			next = s.clock.Now().Add(interval)
+		}
+	})
+}
+
+// Merge combines the values of several streams into one stream, in the order in which they are received.
+// Each stream stops being read once it is closed, its own context is done or the context of s is done,
+// and the merged stream is closed once every stream has stopped being read.
+func (s Stream[T]) Merge(others ...Stream[T]) Stream[T] {
+	return forward(s, func(out chan<- T) {
+		wg := sync.WaitGroup{}
+		for _, stream := range append([]Stream[T]{s}, others...) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case v, ok := <-stream.c:
+						if !ok || !send(s.ctx, out, v) {
+							return
+						}
+					case <-stream.ctx.Done():
+						return
+					case <-s.ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+// Tee copies every value of a stream to n streams.
+// A value is sent to every stream before the next one is received, so the slowest consumer sets the pace.
+// ! this method panics when n is not positive.
+func (s Stream[T]) Tee(n int) []Stream[T] {
+	if n <= 0 {
+		panic("linq: Tee() n must be positive")
+	}
+	outs := make([]chan T, n)
+	res := make([]Stream[T], n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		res[i] = Stream[T]{ctx: s.ctx, c: outs[i], clock: s.clock}
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range s.Values() {
+			for _, out := range outs {
+				if !send(s.ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return res
+}
+
+// FanOut distributes the values of a stream over n streams, every value is sent to only one of them.
+// Each value goes to a stream whose consumer is ready, so slow consumers receive fewer values.
+// ! this method panics when n is not positive.
+func (s Stream[T]) FanOut(n int) []Stream[T] {
+	if n <= 0 {
+		panic("linq: FanOut() n must be positive")
+	}
+	res := make([]Stream[T], n)
+	for i := range res {
+		res[i] = forward(s, func(out chan<- T) {
+			for v := range s.Values() {
+				if !send(s.ctx, out, v) {
+					return
+				}
+			}
+		})
+	}
+	return res
+}
+
+// StreamSelect projects each value of a stream into a new form.
+func StreamSelect[T, S any](s Stream[T], selector func(T) S) Stream[S] {
+	return forward(s, func(out chan<- S) {
+		for v := range s.Values() {
+			if !send(s.ctx, out, selector(v)) {
+				return
+			}
+		}
+	})
+}
+
+// StreamBatch groups the values of a stream into slices of size values.
+// A smaller batch is sent when maxWait has elapsed since its first value was received, or when the source is closed.
+// A maxWait less than or equal to zero waits for full batches.
+// ! this function panics when size is not positive.
+func StreamBatch[T any](s Stream[T], size int, maxWait time.Duration) Stream[[]T] {
+	if size <= 0 {
+		panic("linq: StreamBatch() size must be positive")
+	}
+	return forward(s, func(out chan<- []T) {
+		batch := make([]T, 0, size)
+		var deadline <-chan time.Time
+		flush := func() bool {
+			full := batch
+			batch = make([]T, 0, size)
+			deadline = nil
+			return send(s.ctx, out, full)
+		}
+		for {
+			select {
+			case v, ok := <-s.c:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					deadline = s.clock.After(maxWait)
+				}
+				if len(batch) == size && !flush() {
+					return
+				}
+			case <-deadline:
+				if !flush() {
+					return
+				}
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// forward runs produce on a new goroutine with a new channel, and returns a stream of that channel with the context and clock of s.
+// The channel is closed once produce returns.
+func forward[T, S any](s Stream[T], produce func(out chan<- S)) Stream[S] {
+	out := make(chan S)
+	go func() {
+		defer close(out)
+		produce(out)
+	}()
+	return Stream[S]{ctx: s.ctx, c: out, clock: s.clock}
+}
+
+// send sends v on c, it reports false if ctx is done first.
+func send[T any](ctx context.Context, c chan<- T, v T) bool {
+	select {
+	case c <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive receives a value from c, it reports false if c is closed or ctx is done first.
+func receive[T any](ctx context.Context, c <-chan T) (T, bool) {
+	select {
+	case v, ok := <-c:
+		return v, ok
+	case <-ctx.Done():
+		var defaultValue T
+		return defaultValue, false
+	}
+}
@@ -0,0 +1,192 @@
+package linq
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// feed returns a channel that receives the values and is then closed.
+func feed[T any](values ...T) <-chan T {
+	c := make(chan T)
+	go func() {
+		defer close(c)
+		for _, v := range values {
+			c <- v
+		}
+	}()
+	return c
+}
+
+func Test_Stream_Methods(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	{ // Where and StreamSelect
+		s := FromChannel(ctx, feed(1, 2, 3, 4, 5, 6)).Where(func(i int) bool { return i%2 == 0 })
+		actual := StreamSelect(s, func(i int) string { return strconv.Itoa(i * 10) })
+		assert.Equal([]string{"20", "40", "60"}, FromSeq(actual.Values()).ToSlice())
+	}
+	{ // Chan
+		actual := []int{}
+		for v := range FromChannel(ctx, feed(1, 2)).Where(NoPredict[int]()).Chan() {
+			actual = append(actual, v)
+		}
+		assert.Equal([]int{1, 2}, actual)
+	}
+	{ // Stream is Enumerable
+		assert.Equal([][]int{{1, 2}, {3}}, Chunk(FromChannel(ctx, feed(1, 2, 3)), 2).ToSlice())
+	}
+	{ // Merge
+		a := FromChannel(ctx, feed(1, 2, 3))
+		b := FromChannel(ctx, feed(10, 20))
+		c := FromChannel(ctx, feed[int]())
+		assert.ElementsMatch([]int{1, 2, 3, 10, 20}, FromSeq(a.Merge(b, c).Values()).ToSlice())
+	}
+	{ // Merge stops reading a stream whose own context is done
+		bCtx, cancelB := context.WithCancel(ctx)
+		cancelB()
+		a := FromChannel(ctx, feed(1, 2, 3))
+		b := FromChannel(bCtx, make(chan int))
+		done := make(chan []int)
+		go func() { done <- FromSeq(a.Merge(b).Values()).ToSlice() }()
+		select {
+		case actual := <-done:
+			assert.Equal([]int{1, 2, 3}, actual)
+		case <-time.After(time.Second):
+			assert.Fail("the merged stream was not closed")
+		}
+	}
+	{ // Tee
+		streams := FromChannel(ctx, feed(1, 2, 3)).Tee(2)
+		results := make([][]int, 2)
+		wg := sync.WaitGroup{}
+		for i, s := range streams {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = FromSeq(s.Values()).ToSlice()
+			}()
+		}
+		wg.Wait()
+		assert.Equal([][]int{{1, 2, 3}, {1, 2, 3}}, results)
+		assert.Panics(func() { FromChannel(ctx, feed(1)).Tee(0) })
+	}
+	{ // FanOut
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+		streams := FromChannel(ctx, feed(values...)).FanOut(3)
+		m := sync.Mutex{}
+		actual := []int{}
+		wg := sync.WaitGroup{}
+		for _, s := range streams {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for v := range s.Values() {
+					m.Lock()
+					actual = append(actual, v)
+					m.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.ElementsMatch(values, actual)
+		assert.Panics(func() { FromChannel(ctx, feed(1)).FanOut(0) })
+	}
+}
+
+func Test_StreamBatch(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	{ // full batches, and the rest once the source is closed
+		actual := StreamBatch(FromChannel(ctx, feed(1, 2, 3, 4, 5)), 2, 0)
+		assert.Equal([][]int{{1, 2}, {3, 4}, {5}}, FromSeq(actual.Values()).ToSlice())
+		assert.Panics(func() { StreamBatch(FromChannel(ctx, feed(1)), 0, 0) })
+	}
+	{ // a partial batch is sent once maxWait has elapsed
+		clock := newFakeClock()
+		start := clock.Now()
+		source := make(chan int)
+		batches := StreamBatch(FromChannel(ctx, source).WithClock(clock), 10, time.Second).Chan()
+		// the sends return once StreamBatch has received the values, and the first one starts the maxWait timer
+		source <- 1
+		source <- 2
+		clock.waitForWaiters(1)
+		clock.advance(time.Second - time.Nanosecond)
+		select {
+		case batch := <-batches:
+			assert.Fail("the batch was sent before maxWait elapsed", batch)
+		default:
+		}
+		clock.advance(time.Nanosecond)
+		assert.Equal([]int{1, 2}, <-batches)
+		assert.Equal(time.Second, clock.elapsed(start))
+		source <- 3
+		close(source)
+		assert.Equal([]int{3}, <-batches)
+		_, ok := <-batches
+		assert.False(ok)
+	}
+}
+
+func Test_Stream_Throttle(t *testing.T) {
+	assert := assert.New(t)
+	clock := newFakeClock()
+	start := clock.Now()
+	throttled := FromChannel(context.Background(), feed(1, 2, 3, 4)).WithClock(clock).Throttle(time.Second)
+	received := []time.Duration{}
+	clock.run(func() {
+		for range throttled.Values() {
+			received = append(received, clock.elapsed(start))
+		}
+	})
+	assert.Equal([]time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second}, received)
+}
+
+// Canceling the context stops every operator, even when the source is never closed and nobody reads the output.
+func Test_Stream_Cancel(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	source := make(chan int)
+	branches := FromChannel(ctx, source).Tee(8)
+	outputs := []<-chan int{
+		branches[0].Where(NoPredict[int]()).Chan(),
+		branches[1].Throttle(time.Hour).Chan(),
+		branches[2].Merge(FromChannel(ctx, make(chan int))).Chan(),
+		StreamSelect(branches[3], func(i int) int { return i }).Chan(),
+		branches[4].Chan(),
+	}
+	for _, fan := range branches[5].FanOut(2) {
+		outputs = append(outputs, fan.Chan())
+	}
+	batches := StreamBatch(branches[6], 5, time.Hour).Chan()
+	outputs = append(outputs, StreamSelect(FromChannel(ctx, batches), func(b []int) int { return len(b) }).Chan())
+	outputs = append(outputs, branches[7].Where(NoPredict[int]()).Chan())
+	source <- 1
+	cancel()
+
+	closed := func(c <-chan int) bool {
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case _, ok := <-c:
+				if !ok {
+					return true
+				}
+			case <-timeout:
+				return false
+			}
+		}
+	}
+	for i, out := range outputs {
+		assert.True(closed(out), i)
+	}
+	_, ok := <-FromChannel(ctx, source).Where(NoPredict[int]()).Chan()
+	assert.False(ok)
+}
@@ -0,0 +1,196 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to the concurrent operators, replace it with a fake clock to test rate limits, backoffs and timeouts.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RetryPolicy specifies how the concurrent operators retry a delegate that returns an error.
+// The zero value does not retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls per element, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, no cap when not positive.
+	MaxBackoff time.Duration
+	// Multiplier is the factor applied to the delay after each retry, 2 when less than 1.
+	Multiplier float64
+	// Jitter randomizes each delay by up to the specified fraction of it in either direction, such as 0.1 for ±10%.
+	Jitter float64
+	// Retryable reports whether an error returned by the delegate is worth retrying, every error is retried when nil.
+	// Panics and the errors of a done context are never retried.
+	Retryable func(error) bool
+}
+
+// maxBackoff is the longest delay between two attempts, it caps the backoff when MaxBackoff is not set.
+const maxBackoff = time.Duration(math.MaxInt64)
+
+// backoff returns the delay before the specified retry, the first retry being 1.
+// The delay never exceeds MaxBackoff, or the largest time.Duration when MaxBackoff is not set.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	limit := float64(maxBackoff)
+	if p.MaxBackoff > 0 {
+		limit = float64(p.MaxBackoff)
+	}
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < retry && delay < limit; i++ {
+		delay *= multiplier
+	}
+	delay = min(delay, limit)
+	if p.Jitter > 0 {
+		// the jitter is clamped again, so that a delay at the cap is only ever shortened
+		delay = min(delay*(1+p.Jitter*(2*rand.Float64()-1)), limit)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which does not fit in a time.Duration
+	if delay >= float64(maxBackoff) {
+		return maxBackoff
+	}
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	var panicErr *DelegatePanicError
+	if errors.As(err, &panicErr) {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// tokenBucket is a rate limiter that allows rate events per second with bursts of up to burst events.
+type tokenBucket struct {
+	m      sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(clock Clock, rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{clock: clock, rate: rate, burst: float64(burst), tokens: float64(burst), last: clock.Now()}
+}
+
+// wait blocks until a token is available and takes it, or returns ctx.Err() once ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.m.Lock()
+		now := b.clock.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.m.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.m.Unlock()
+
+		select {
+		case <-b.clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// executor runs a delegate for an element according to the rate limit, retry policy and timeout of AsyncOptions.
+type executor[T, R any] struct {
+	opts     AsyncOptions
+	clock    Clock
+	limiter  *tokenBucket
+	delegate func(context.Context, T) (R, error)
+}
+
+func newExecutor[T, R any](opts AsyncOptions, delegate func(context.Context, T) (R, error)) executor[T, R] {
+	e := executor[T, R]{opts: opts, clock: opts.Clock, delegate: delegate}
+	if e.clock == nil {
+		e.clock = realClock{}
+	}
+	if opts.RateLimit > 0 {
+		e.limiter = newTokenBucket(e.clock, opts.RateLimit, opts.Burst)
+	}
+	return e
+}
+
+// run calls the delegate for the element at index until an attempt succeeds or the retry policy gives up.
+func (e executor[T, R]) run(ctx context.Context, index int, elem T) (R, error) {
+	var result R
+	var err error
+	for attempt := 1; ; attempt++ {
+		if e.limiter != nil {
+			if err := e.limiter.wait(ctx); err != nil {
+				return result, &IndexedError{Index: index, Err: err}
+			}
+		}
+		result, err = e.attempt(ctx, index, elem)
+		if err == nil || attempt >= e.opts.Retry.MaxAttempts || ctx.Err() != nil || !e.opts.Retry.retryable(err) {
+			return result, err
+		}
+		select {
+		case <-e.clock.After(e.opts.Retry.backoff(attempt)):
+		case <-ctx.Done():
+			return result, err
+		}
+	}
+}
+
+// attempt calls the delegate once, and gives up waiting for it once the timeout elapses.
+// The delegate receives a context that is canceled at the timeout, it keeps running in the background if it ignores it.
+func (e executor[T, R]) attempt(ctx context.Context, index int, elem T) (R, error) {
+	if e.opts.Timeout <= 0 {
+		return callDelegate(ctx, index, elem, e.delegate)
+	}
+	attemptCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	type outcome struct {
+		result R
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := callDelegate(attemptCtx, index, elem, e.delegate)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-e.clock.After(e.opts.Timeout):
+		cancel(context.DeadlineExceeded)
+		var defaultValue R
+		return defaultValue, &IndexedError{Index: index, Err: context.DeadlineExceeded}
+	case <-ctx.Done():
+		var defaultValue R
+		return defaultValue, &IndexedError{Index: index, Err: ctx.Err()}
+	}
+}
@@ -0,0 +1,314 @@
+package linq
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+type fakeClock struct {
+	m       sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: c.now.Add(d), c: ch})
+	return ch
+}
+
+// advanceToNext moves the time to the earliest deadline and fires the waiters that are due, it reports whether there was any waiter.
+func (c *fakeClock) advanceToNext() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if len(c.waiters) == 0 {
+		return false
+	}
+	slices.SortFunc(c.waiters, func(a, b fakeWaiter) int { return a.deadline.Compare(b.deadline) })
+	c.now = c.waiters[0].deadline
+	for len(c.waiters) > 0 && !c.waiters[0].deadline.After(c.now) {
+		c.waiters[0].c <- c.now
+		c.waiters = c.waiters[1:]
+	}
+	return true
+}
+
+// advance moves the time forward by d and fires the waiters that are due.
+func (c *fakeClock) advance(d time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.c <- c.now
+	}
+	c.waiters = pending
+}
+
+// waitForWaiters blocks until at least n waiters are waiting on the clock.
+func (c *fakeClock) waitForWaiters(n int) {
+	for {
+		c.m.Lock()
+		count := len(c.waiters)
+		c.m.Unlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// run calls f and moves the time to the next deadline whenever something waits on the clock, until f returns.
+func (c *fakeClock) run(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Millisecond):
+			c.advanceToNext()
+		}
+	}
+}
+
+// elapsed returns the fake time elapsed since start.
+func (c *fakeClock) elapsed(start time.Time) time.Duration {
+	return c.Now().Sub(start)
+}
+
+func Test_Async_RateLimit(t *testing.T) {
+	assert := assert.New(t)
+	clock := newFakeClock()
+	start := clock.Now()
+	m := sync.Mutex{}
+	starts := []time.Duration{}
+	opts := AsyncOptions{Limit: 5, RateLimit: 1, Burst: 2, Clock: clock}
+	clock.run(func() {
+		_, err := MapConcurrentWithOptions(New(make([]int, 5)), opts, func(i int) (int, error) {
+			m.Lock()
+			starts = append(starts, clock.elapsed(start))
+			m.Unlock()
+			return i, nil
+		})
+		assert.NoError(err)
+	})
+	slices.Sort(starts)
+	assert.Equal([]time.Duration{0, 0, time.Second, 2 * time.Second, 3 * time.Second}, starts)
+}
+
+func Test_Async_Retry(t *testing.T) {
+	assert := assert.New(t)
+	errTransient := errors.New("transient")
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond}
+	{ // succeeds after retries with an exponential backoff
+		clock := newFakeClock()
+		start := clock.Now()
+		attempts := []time.Duration{}
+		clock.run(func() {
+			actual, err := MapConcurrentWithOptions(New([]int{7}), AsyncOptions{Retry: policy, Clock: clock}, func(i int) (int, error) {
+				attempts = append(attempts, clock.elapsed(start))
+				if len(attempts) < 3 {
+					return 0, errTransient
+				}
+				return i, nil
+			})
+			assert.NoError(err)
+			assert.Equal([]int{7}, actual)
+		})
+		assert.Equal([]time.Duration{0, 100 * time.Millisecond, 300 * time.Millisecond}, attempts)
+	}
+	{ // gives up after MaxAttempts
+		clock := newFakeClock()
+		calls := atomic.Int32{}
+		clock.run(func() {
+			_, err := MapConcurrentWithOptions(New([]int{7}), AsyncOptions{Retry: policy, Clock: clock}, func(i int) (int, error) {
+				calls.Add(1)
+				return 0, errTransient
+			})
+			assert.ErrorIs(err, errTransient)
+		})
+		assert.Equal(int32(3), calls.Load())
+	}
+	{ // errors that are not retryable and panics are not retried
+		clock := newFakeClock()
+		calls := atomic.Int32{}
+		permanent := policy
+		permanent.Retryable = func(err error) bool { return !errors.Is(err, errTransient) }
+		clock.run(func() {
+			_, err := MapConcurrentWithOptions(New([]int{1, 2}), AsyncOptions{Retry: permanent, Mode: CollectAll, Clock: clock}, func(i int) (int, error) {
+				calls.Add(1)
+				if i == 1 {
+					panic("boom")
+				}
+				return 0, errTransient
+			})
+			var panicErr *DelegatePanicError
+			assert.ErrorAs(err, &panicErr)
+			assert.ErrorIs(err, errTransient)
+		})
+		assert.Equal(int32(2), calls.Load())
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	assert := assert.New(t)
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 500 * time.Millisecond}
+	actual := []time.Duration{}
+	for retry := 1; retry <= 5; retry++ {
+		actual = append(actual, policy.backoff(retry))
+	}
+	assert.Equal([]time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}, actual)
+
+	policy.Multiplier = 3
+	assert.Equal(300*time.Millisecond, policy.backoff(2))
+
+	policy.Jitter = 0.1
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		assert.GreaterOrEqual(delay, 90*time.Millisecond)
+		assert.LessOrEqual(delay, 110*time.Millisecond)
+	}
+
+	// a jittered delay never exceeds MaxBackoff once the backoff has reached it
+	policy = RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 500 * time.Millisecond, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(10)
+		assert.GreaterOrEqual(delay, 250*time.Millisecond)
+		assert.LessOrEqual(delay, 500*time.Millisecond)
+	}
+}
+
+// Without MaxBackoff the delay grows until it reaches the largest time.Duration, it never overflows.
+func Test_RetryPolicy_Backoff_Unbounded(t *testing.T) {
+	assert := assert.New(t)
+	for _, policy := range []RetryPolicy{
+		{InitialBackoff: time.Second},
+		{InitialBackoff: time.Second, Multiplier: 1e300},
+		{InitialBackoff: time.Second, Jitter: 0.5},
+	} {
+		previous := time.Duration(0)
+		for retry := 1; retry <= 2000; retry++ {
+			delay := policy.backoff(retry)
+			assert.Positive(delay)
+			if policy.Jitter == 0 {
+				assert.GreaterOrEqual(delay, previous)
+			}
+			previous = delay
+		}
+		assert.LessOrEqual(previous, time.Duration(math.MaxInt64))
+		if policy.Jitter == 0 {
+			assert.Equal(time.Duration(math.MaxInt64), previous)
+		}
+	}
+
+	// the executor waits the capped delays between a large number of attempts
+	clock := &recordingClock{Clock: newFakeClock()}
+	calls := 0
+	opts := AsyncOptions{Retry: RetryPolicy{MaxAttempts: 100, InitialBackoff: time.Second}, Clock: clock}
+	_, err := MapConcurrentWithOptions(New([]int{1}), opts, func(i int) (int, error) {
+		calls++
+		return 0, errors.New("transient")
+	})
+	assert.Error(err)
+	assert.Equal(100, calls)
+	assert.Len(clock.delays, 99)
+	for _, delay := range clock.delays {
+		assert.Positive(delay)
+	}
+	assert.Equal(time.Duration(math.MaxInt64), clock.delays[98])
+}
+
+// recordingClock records the requested delays and fires them immediately.
+type recordingClock struct {
+	Clock
+	m      sync.Mutex
+	delays []time.Duration
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func Test_Async_Timeout(t *testing.T) {
+	assert := assert.New(t)
+	blockUntilCanceled := func(ctx context.Context, i int) (int, error) {
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	}
+	{ // an attempt that outlives the timeout fails with context.DeadlineExceeded
+		clock := newFakeClock()
+		start := clock.Now()
+		clock.run(func() {
+			_, err := MapConcurrentContext(context.Background(), New([]int{1}), AsyncOptions{Timeout: time.Second, Clock: clock}, blockUntilCanceled)
+			assert.ErrorIs(err, context.DeadlineExceeded)
+		})
+		assert.Equal(time.Second, clock.elapsed(start))
+	}
+	{ // timeouts are retried
+		clock := newFakeClock()
+		calls := atomic.Int32{}
+		opts := AsyncOptions{Timeout: time.Second, Retry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second}, Clock: clock}
+		clock.run(func() {
+			actual, err := MapConcurrentContext(context.Background(), New([]int{5}), opts, func(ctx context.Context, i int) (int, error) {
+				if calls.Add(1) < 3 {
+					return blockUntilCanceled(ctx, i)
+				}
+				return i, nil
+			})
+			assert.NoError(err)
+			assert.Equal([]int{5}, actual)
+		})
+		assert.Equal(int32(3), calls.Load())
+	}
+	{ // a delegate that returns in time is not affected
+		clock := newFakeClock()
+		actual, err := MapConcurrentWithOptions(New([]int{1, 2}), AsyncOptions{Timeout: time.Second, Clock: clock}, func(i int) (int, error) {
+			return i * 3, nil
+		})
+		assert.NoError(err)
+		assert.Equal([]int{3, 6}, actual)
+	}
+}
@@ -0,0 +1,72 @@
+package linq
+
+import "slices"
+
+// Chunk splits the elements of a sequence into chunks of the specified size, the last chunk may contain fewer elements.
+// ! this function panics when size is not positive.
+func Chunk[T any](items Enumerable[T], size int) Linq[[]T] {
+	if size <= 0 {
+		panic("linq: Chunk() size must be positive")
+	}
+	res := [][]T{}
+	current := make([]T, 0, size)
+	for elem := range items.Values() {
+		current = append(current, elem)
+		if len(current) == size {
+			res = append(res, current)
+			current = make([]T, 0, size)
+		}
+	}
+	if len(current) > 0 {
+		res = append(res, slices.Clip(current))
+	}
+	return New(res)
+}
+
+// Window returns the sliding windows of the specified size over a sequence, a new window starts every step elements.
+// Only full windows are returned, so a sequence shorter than size yields no window.
+// ! this function panics when size or step is not positive.
+func Window[T any](items Enumerable[T], size, step int) Linq[[]T] {
+	if size <= 0 || step <= 0 {
+		panic("linq: Window() size and step must be positive")
+	}
+	source := collect(items)
+	res := [][]T{}
+	for start := 0; start+size <= len(source); start += step {
+		res = append(res, slices.Clone(source[start:start+size]))
+	}
+	return New(res)
+}
+
+// Pairwise returns a sequence resulting from applying a function to each element and its predecessor,
+// the first element has no predecessor so the result has one element less than the source.
+func Pairwise[T, R any](items Enumerable[T], resultSelector func(previous, current T) R) Linq[R] {
+	res := []R{}
+	var previous T
+	first := true
+	for elem := range items.Values() {
+		if !first {
+			res = append(res, resultSelector(previous, elem))
+		}
+		previous, first = elem, false
+	}
+	return New(res)
+}
+
+// Segment splits a sequence into tumbling windows, a new window starts at every element that satisfies the predicate.
+// The predicate is not called for the first element, which always starts the first window.
+func Segment[T any](items Enumerable[T], newSegment func(T) bool) Linq[[]T] {
+	res := [][]T{}
+	var current []T
+	for elem := range items.Values() {
+		if current != nil && newSegment(elem) {
+			res = append(res, current)
+			current = nil
+		}
+		current = append(current, elem)
+	}
+	if current != nil {
+		res = append(res, current)
+	}
+	return New(res)
+}
@@ -0,0 +1,47 @@
+package linq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Window_Functions(t *testing.T) {
+	assert := assert.New(t)
+	si := New([]int{1, 2, 3, 4, 5, 6, 7})
+	empty := New([]int{})
+	{ // Chunk
+		assert.Equal([][]int{{1, 2, 3}, {4, 5, 6}, {7}}, Chunk(si, 3).ToSlice())
+		assert.Equal([][]int{{1, 2, 3, 4, 5, 6, 7}}, Chunk(si, 10).ToSlice())
+		assert.Empty(Chunk(empty, 3).ToSlice())
+		assert.Panics(func() { Chunk(si, 0) })
+	}
+	{ // appending to a chunk does not overwrite the next chunk
+		chunks := Chunk(si, 2).ToSlice()
+		_ = append(chunks[0], 100)
+		assert.Equal([]int{3, 4}, chunks[1])
+	}
+	{ // Window
+		assert.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}, {5, 6, 7}}, Window(si, 3, 1).ToSlice())
+		assert.Equal([][]int{{1, 2}, {4, 5}}, Window(si, 2, 3).ToSlice())
+		assert.Empty(Window(si, 8, 1).ToSlice())
+		assert.Panics(func() { Window(si, 2, 0) })
+	}
+	{ // moving average composes with Select and NumberLinq
+		averages := Select(Window(si, 3, 1), func(w []int) float64 {
+			return NewNumberLinq[int, int](w).Average(func(i int) int { return i })
+		})
+		assert.Equal([]float64{2, 3, 4, 5, 6}, averages.ToSlice())
+	}
+	{ // Pairwise
+		deltas := Pairwise(New([]int{1, 4, 9, 16}), func(previous, current int) int { return current - previous })
+		assert.Equal([]int{3, 5, 7}, deltas.ToSlice())
+		assert.Empty(Pairwise(New([]int{1}), func(previous, current int) int { return 0 }).ToSlice())
+	}
+	{ // Segment
+		lines := New([]string{"# a", "1", "2", "# b", "# c", "3"})
+		actual := Segment(lines, func(s string) bool { return s[0] == '#' })
+		assert.Equal([][]string{{"# a", "1", "2"}, {"# b"}, {"# c", "3"}}, actual.ToSlice())
+		assert.Empty(Segment(empty, func(int) bool { return true }).ToSlice())
+	}
+}
@@ -0,0 +1,109 @@
+package linq
+
+import "iter"
+
+// Pair is a tuple of two values.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair creates a Pair from two values.
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Zip applies a specified function to the corresponding elements of two sequences.
+// The result is as long as the shorter sequence.
+// first is pulled before second and the enumeration stops at the first exhausted sequence,
+// so nothing more is read from second once first is exhausted,
+// but the element of first pulled for the last, incomplete pair is dropped when second is shorter.
+func Zip[A, B, R any](first Enumerable[A], second Enumerable[B], resultSelector func(A, B) R) Linq[R] {
+	nextA, stopA := iter.Pull(first.Values())
+	defer stopA()
+	nextB, stopB := iter.Pull(second.Values())
+	defer stopB()
+	res := []R{}
+	for {
+		a, ok := nextA()
+		if !ok {
+			return New(res)
+		}
+		b, ok := nextB()
+		if !ok {
+			return New(res)
+		}
+		res = append(res, resultSelector(a, b))
+	}
+}
+
+// ZipPairs produces a sequence of pairs with the corresponding elements of two sequences.
+// The result is as long as the shorter sequence.
+func ZipPairs[A, B any](first Enumerable[A], second Enumerable[B]) Linq[Pair[A, B]] {
+	return Zip(first, second, NewPair[A, B])
+}
+
+// Zip3 applies a specified function to the corresponding elements of three sequences.
+// The result is as long as the shortest sequence.
+// The sequences are pulled from the first to the last and the enumeration stops at the first exhausted one,
+// so nothing more is read from the sequences after it,
+// but the elements pulled from the sequences before it for the last, incomplete triple are dropped.
+func Zip3[A, B, C, R any](first Enumerable[A], second Enumerable[B], third Enumerable[C], resultSelector func(A, B, C) R) Linq[R] {
+	nextA, stopA := iter.Pull(first.Values())
+	defer stopA()
+	nextB, stopB := iter.Pull(second.Values())
+	defer stopB()
+	nextC, stopC := iter.Pull(third.Values())
+	defer stopC()
+	res := []R{}
+	for {
+		a, ok := nextA()
+		if !ok {
+			return New(res)
+		}
+		b, ok := nextB()
+		if !ok {
+			return New(res)
+		}
+		c, ok := nextC()
+		if !ok {
+			return New(res)
+		}
+		res = append(res, resultSelector(a, b, c))
+	}
+}
+
+// ZipLongest applies a specified function to the corresponding elements of two sequences.
+// The result is as long as the longer sequence, the missing elements of the shorter sequence are replaced by the fill values.
+func ZipLongest[A, B, R any](first Enumerable[A], second Enumerable[B], fillFirst A, fillSecond B, resultSelector func(A, B) R) Linq[R] {
+	nextA, stopA := iter.Pull(first.Values())
+	defer stopA()
+	nextB, stopB := iter.Pull(second.Values())
+	defer stopB()
+	res := []R{}
+	for {
+		a, okA := nextA()
+		b, okB := nextB()
+		if !okA && !okB {
+			return New(res)
+		}
+		if !okA {
+			a = fillFirst
+		}
+		if !okB {
+			b = fillSecond
+		}
+		res = append(res, resultSelector(a, b))
+	}
+}
+
+// Unzip splits a sequence of pairs into the sequence of their first values and the sequence of their second values.
+func Unzip[A, B any](pairs Enumerable[Pair[A, B]]) (Linq[A], Linq[B]) {
+	as := []A{}
+	bs := []B{}
+	for p := range pairs.Values() {
+		as = append(as, p.First)
+		bs = append(bs, p.Second)
+	}
+	return New(as), New(bs)
+}
@@ -0,0 +1,99 @@
+package linq
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Zip_Functions(t *testing.T) {
+	assert := assert.New(t)
+	numbers := New([]int{1, 2, 3, 4})
+	words := New([]string{"one", "two", "three"})
+	{ // Zip
+		actual := Zip(numbers, words, func(i int, s string) string { return strconv.Itoa(i) + "=" + s })
+		assert.Equal([]string{"1=one", "2=two", "3=three"}, actual.ToSlice())
+	}
+	{ // ZipPairs
+		actual := ZipPairs(words, numbers)
+		assert.Equal([]Pair[string, int]{{"one", 1}, {"two", 2}, {"three", 3}}, actual.ToSlice())
+	}
+	{ // Zip3
+		flags := New([]bool{true, false, true, false, true})
+		actual := Zip3(numbers, words, flags, func(i int, s string, b bool) string {
+			if b {
+				return s
+			}
+			return strconv.Itoa(i)
+		})
+		assert.Equal([]string{"one", "2", "three"}, actual.ToSlice())
+	}
+	{ // ZipLongest
+		actual := ZipLongest(numbers, words, -1, "?", func(i int, s string) string { return strconv.Itoa(i) + "=" + s })
+		assert.Equal([]string{"1=one", "2=two", "3=three", "4=?"}, actual.ToSlice())
+		actual = ZipLongest(New([]int{}), words, -1, "?", func(i int, s string) string { return strconv.Itoa(i) + "=" + s })
+		assert.Equal([]string{"-1=one", "-1=two", "-1=three"}, actual.ToSlice())
+	}
+	{ // Unzip
+		first, second := Unzip(ZipPairs(numbers, words))
+		assert.Equal([]int{1, 2, 3}, first.ToSlice())
+		assert.Equal([]string{"one", "two", "three"}, second.ToSlice())
+	}
+	{ // empty sequences
+		first, second := Unzip(New([]Pair[int, string]{}))
+		assert.Empty(first.ToSlice())
+		assert.Empty(second.ToSlice())
+		assert.Empty(Zip(numbers, New([]string{}), func(i int, s string) int { return i }).ToSlice())
+	}
+}
+
+// Zip must not consume an element of a single-pass source that has no counterpart.
+func Test_Zip_SinglePassSources(t *testing.T) {
+	assert := assert.New(t)
+	numbers := func() chan int {
+		c := make(chan int, 5)
+		for i := range 5 {
+			c <- i
+		}
+		close(c)
+		return c
+	}
+	{ // Zip does not read a longer second source past the end of first
+		c := numbers()
+		actual := ZipPairs(Slice([]string{"a", "b"}), Chan(c))
+		assert.Equal([]Pair[string, int]{{"a", 0}, {"b", 1}}, actual.ToSlice())
+		assert.Equal([]int{2, 3, 4}, NewFromChannel(c).ToSlice())
+	}
+	{ // Zip does not read a longer stream past the end of first
+		s := FromChannel(context.Background(), numbers())
+		actual := ZipPairs(Slice([]string{"a"}), s)
+		assert.Equal([]Pair[string, int]{{"a", 0}}, actual.ToSlice())
+		assert.Equal([]int{1, 2, 3, 4}, FromSeq(s.Values()).ToSlice())
+	}
+	{ // Zip drops the element of first pulled for the last, incomplete pair
+		c := numbers()
+		actual := ZipPairs(Chan(c), Slice([]string{"a", "b"}))
+		assert.Equal([]Pair[int, string]{{0, "a"}, {1, "b"}}, actual.ToSlice())
+		assert.Equal([]int{3, 4}, NewFromChannel(c).ToSlice())
+	}
+	{ // Zip3 stops at the exhausted second source without pulling third
+		c := numbers()
+		third := make(chan bool, 3)
+		third <- true
+		third <- false
+		third <- true
+		close(third)
+		actual := Zip3(Slice([]string{"a"}), Chan(c), Chan(third), func(s string, i int, b bool) string { return s })
+		assert.Equal([]string{"a"}, actual.ToSlice())
+		assert.Equal([]int{1, 2, 3, 4}, NewFromChannel(c).ToSlice())
+		assert.Equal([]bool{false, true}, NewFromChannel(third).ToSlice())
+	}
+	{ // Zip3 with a shorter third source
+		c := numbers()
+		actual := Zip3(Slice([]string{"a", "b", "c"}), Chan(c), Slice([]bool{true}), func(s string, i int, b bool) string { return s })
+		assert.Equal([]string{"a"}, actual.ToSlice())
+		assert.Equal([]int{2, 3, 4}, NewFromChannel(c).ToSlice())
+	}
+}